@@ -0,0 +1,17 @@
+action "bluestonepim_webhook_test_delivery" "ping" {
+  config {
+    webhook_id = bluestonepim_webhook.my_webhook.id
+  }
+}
+
+# Send a test message after the webhook is created or its URL changes.
+resource "terraform_data" "webhook_url" {
+  input = bluestonepim_webhook.my_webhook.url
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.bluestonepim_webhook_test_delivery.ping]
+    }
+  }
+}
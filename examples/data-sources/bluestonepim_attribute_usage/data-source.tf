@@ -0,0 +1,11 @@
+data "bluestonepim_attribute_usage" "color" {
+  number = "color"
+}
+
+output "color_mandatory_categories" {
+  value = [for c in data.bluestonepim_attribute_usage.color.categories : c.name if c.mandatory]
+}
+
+output "color_product_count" {
+  value = data.bluestonepim_attribute_usage.color.product_count
+}
@@ -0,0 +1,15 @@
+data "bluestonepim_category_path" "sneakers" {
+  path = "Apparel > Shoes > Sneakers"
+}
+
+# or, relative to a category and with a different separator
+
+data "bluestonepim_category_path" "boots" {
+  root_id   = data.bluestonepim_category_path.sneakers.segments[0].id
+  path      = "Shoes/Boots"
+  separator = "/"
+}
+
+output "sneakers_category_id" {
+  value = data.bluestonepim_category_path.sneakers.id
+}
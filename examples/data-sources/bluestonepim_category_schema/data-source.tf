@@ -0,0 +1,16 @@
+data "bluestonepim_category_path" "sneakers" {
+  path = "Apparel > Shoes > Sneakers"
+}
+
+data "bluestonepim_category_schema" "sneakers" {
+  category_id = data.bluestonepim_category_path.sneakers.id
+}
+
+output "sneakers_mandatory_attributes" {
+  value = [for a in data.bluestonepim_category_schema.sneakers.attributes : a.number if a.mandatory]
+}
+
+# Feed the schema to code generation in other repositories
+output "sneakers_schema_json" {
+  value = data.bluestonepim_category_schema.sneakers.json
+}
@@ -0,0 +1,13 @@
+data "bluestonepim_context" "dutch" {
+  locale = "nl-NL"
+}
+
+# or
+
+data "bluestonepim_context" "by_name" {
+  name = "Dutch"
+}
+
+output "dutch_fallback_locales" {
+  value = [for c in data.bluestonepim_context.dutch.fallback_chain : c.locale]
+}
@@ -0,0 +1,5 @@
+data "bluestonepim_contexts" "all" {}
+
+output "context_ids_by_locale" {
+  value = { for c in data.bluestonepim_contexts.all.contexts : c.locale => c.id }
+}
@@ -0,0 +1,13 @@
+data "bluestonepim_webhook" "by_id" {
+  id = "my-webhook-id"
+}
+
+# or
+
+data "bluestonepim_webhook" "by_url" {
+  url = "https://example.test"
+}
+
+output "webhook_healthy" {
+  value = data.bluestonepim_webhook.by_url.active && data.bluestonepim_webhook.by_url.recent_failure_count == 0
+}
@@ -0,0 +1,11 @@
+data "bluestonepim_webhook_event_types" "all" {}
+
+# Subscribe to every product event
+resource "bluestonepim_webhook" "products" {
+  url    = "https://example.test/products"
+  secret = "my-secret"
+  event_types = [
+    for event_type in data.bluestonepim_webhook_event_types.all.event_types : event_type
+    if startswith(event_type, "PRODUCT_")
+  ]
+}
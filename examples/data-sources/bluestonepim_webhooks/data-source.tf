@@ -0,0 +1,8 @@
+data "bluestonepim_webhooks" "all" {}
+
+output "failing_webhooks" {
+  value = [
+    for webhook in data.bluestonepim_webhooks.all.webhooks : webhook.url
+    if webhook.last_delivery_status == "ERROR"
+  ]
+}
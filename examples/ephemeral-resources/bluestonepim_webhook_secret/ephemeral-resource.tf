@@ -0,0 +1,12 @@
+ephemeral "bluestonepim_webhook_secret" "my_webhook" {
+  length = 32
+}
+
+resource "bluestonepim_webhook" "my_webhook" {
+  url               = "https://example.test"
+  secret_wo         = ephemeral.bluestonepim_webhook_secret.my_webhook.value
+  secret_wo_version = 1
+  event_types = [
+    "PRODUCT_CREATED",
+  ]
+}
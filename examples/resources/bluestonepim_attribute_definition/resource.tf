@@ -5,3 +5,52 @@ resource "bluestonepim_attribute_definition" "my_attribute_definition" {
   content_type = "text/markdown"
   description  = "This is a description of the attribute definition."
 }
+
+resource "bluestonepim_attribute_definition" "color" {
+  name      = "Color"
+  number    = "color"
+  data_type = "single_select"
+
+  # Fail the plan instead of warning when a change of data_type would replace
+  # the attribute while products still have a color
+  prevent_destroy_when_in_use = true
+
+  restrictions = {
+    enum = {
+      type = "text"
+      # Values are keyed by their number, renaming a value keeps its value_id
+      values = {
+        "red"  = { value = "Red" }
+        "blue" = { value = "Blue", metadata = "#0000ff" }
+      }
+    }
+  }
+}
+
+resource "bluestonepim_attribute_definition" "weight" {
+  name      = "Weight"
+  number    = "weight"
+  data_type = "decimal"
+  unit      = "kg"
+
+  restrictions = {
+    range = {
+      min  = "0"
+      max  = "1000"
+      step = "0.1"
+    }
+  }
+}
+
+resource "bluestonepim_attribute_definition" "size" {
+  name      = "Size"
+  number    = "size"
+  data_type = "text"
+
+  # Take over the attribute definition with this number when it already
+  # exists in Bluestone PIM, instead of failing
+  adopt_existing = true
+
+  # Allow another attribute definition with the same name
+  duplicate_validation = "none"
+}
@@ -0,0 +1,2 @@
+# Enum values can be imported using the attribute definition id and the number of the value
+terraform import bluestonepim_attribute_enum_value.acme <attribute_definition_id>:acme
@@ -0,0 +1,22 @@
+# Owned by the central data team, values added elsewhere are kept
+resource "bluestonepim_attribute_definition" "brand" {
+  name      = "Brand"
+  number    = "brand"
+  data_type = "single_select"
+
+  restrictions = {
+    enum = {
+      exclusive_values = false
+      values = {
+        "generic" = { value = "Generic" }
+      }
+    }
+  }
+}
+
+# Owned by a brand team
+resource "bluestonepim_attribute_enum_value" "acme" {
+  attribute_definition_id = bluestonepim_attribute_definition.brand.id
+  number                  = "acme"
+  value                   = "ACME"
+}
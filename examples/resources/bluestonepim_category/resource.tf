@@ -7,4 +7,40 @@ resource "bluestonepim_category" "my_category" {
   name      = "My category"
   number    = "my-category-key"
   parent_id = bluestonepim_category.my_parent_category.id
+  position  = 0
+}
+
+resource "bluestonepim_category" "my_other_category" {
+  name       = "My other category"
+  number     = "my-other-category-key"
+  parent_id  = bluestonepim_category.my_parent_category.id
+  sort_after = bluestonepim_category.my_category.id
+}
+
+resource "bluestonepim_category" "products" {
+  name   = "Products"
+  number = "products"
+
+  # Set to false and apply before removing the category
+  deletion_protection = true
+}
+
+resource "bluestonepim_category" "seasonal" {
+  name      = "Seasonal"
+  number    = "seasonal"
+  parent_id = bluestonepim_category.products.id
+
+  # Move the products and subcategories to the parent when this category is
+  # removed
+  on_delete   = "reassign"
+  reassign_to = bluestonepim_category.products.id
+}
+
+resource "bluestonepim_category" "existing" {
+  name   = "Existing category"
+  number = "existing-category"
+
+  # Take over the category with this number when it already exists in
+  # Bluestone PIM, instead of failing
+  adopt_existing = true
 }
@@ -0,0 +1,2 @@
+# Category attributes can be imported using the category id and the attribute definition id
+terraform import bluestonepim_category_attribute.my_category_attribute <category_id>:<attribute_definition_id>
@@ -12,3 +12,23 @@ resource "bluestonepim_category_attribute" "my_category_attribute" {
   attribute_definition_id = bluestonepim_attribute_definition.my_attribute_definition.id
   mandatory               = true
 }
+
+resource "bluestonepim_attribute_definition" "warranty" {
+  name      = "Warranty (years)"
+  data_type = "integer"
+}
+
+# Give the category a default value without pushing the attribute onto the
+# products, and remove the product values when the attribute is unassigned.
+resource "bluestonepim_category_attribute" "warranty" {
+  category_id             = bluestonepim_category.my_category.id
+  attribute_definition_id = bluestonepim_attribute_definition.warranty.id
+  default_value           = "2"
+  force_classification    = false
+  on_unassign             = "remove"
+
+  # Removing the product values can take a while for large categories
+  timeouts {
+    delete = "15m"
+  }
+}
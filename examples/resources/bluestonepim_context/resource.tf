@@ -9,3 +9,10 @@ resource "bluestonepim_context" "nl_be" {
   locale      = "nl-BE"
   fallback_id = bluestonepim_context.nl_nl.id
 }
+
+# Adopt the archived German context instead of failing on create
+resource "bluestonepim_context" "de_de" {
+  name           = "German (Germany)"
+  locale         = "de-DE"
+  adopt_archived = true
+}
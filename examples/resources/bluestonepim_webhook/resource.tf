@@ -7,3 +7,14 @@ resource "bluestonepim_webhook" "my_webhook" {
     "PRODUCT_SYNC_DONE"
   ]
 }
+
+# The secret can also be passed as a write-only value, so it is never stored
+# in the state. Increment secret_wo_version to rotate the secret.
+resource "bluestonepim_webhook" "my_write_only_webhook" {
+  url               = "https://example.test/write-only"
+  secret_wo         = var.webhook_secret
+  secret_wo_version = 1
+  event_types = [
+    "PRODUCT_CREATED",
+  ]
+}
@@ -0,0 +1,2 @@
+# Subscriptions can be imported using the webhook ID and a comma separated list of event types
+terraform import bluestonepim_webhook_subscription.catalog_team <webhook_id>:CATEGORY_CREATED,CATEGORY_REMOVED
@@ -0,0 +1,15 @@
+# Central webhook, managed by the integration team
+resource "bluestonepim_webhook" "integration" {
+  url                   = "https://example.test/integration"
+  secret                = "my-secret"
+  exclusive_event_types = false
+}
+
+# Subscriptions owned by a domain team, possibly in another workspace
+resource "bluestonepim_webhook_subscription" "catalog_team" {
+  webhook_id = bluestonepim_webhook.integration.id
+  event_types = [
+    "CATEGORY_CREATED",
+    "CATEGORY_REMOVED",
+  ]
+}
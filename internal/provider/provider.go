@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -13,17 +15,23 @@ import (
 	"github.com/labd/bluestonepim-go-sdk/notification_external"
 	"github.com/labd/bluestonepim-go-sdk/pim"
 	"github.com/labd/terraform-provider-bluestonepim/internal/resources/attribute_definition"
+	"github.com/labd/terraform-provider-bluestonepim/internal/resources/attribute_enum_value"
 	"github.com/labd/terraform-provider-bluestonepim/internal/resources/category"
 	"github.com/labd/terraform-provider-bluestonepim/internal/resources/category_attribute"
 	bpcontext "github.com/labd/terraform-provider-bluestonepim/internal/resources/context"
 	"github.com/labd/terraform-provider-bluestonepim/internal/resources/webhook"
+	"github.com/labd/terraform-provider-bluestonepim/internal/resources/webhook_subscription"
 	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 )
 
 // Ensure BluestonePimProvider satisfies various provider interfaces.
-var _ provider.Provider = &BluestonePimProvider{}
+var (
+	_ provider.Provider                       = &BluestonePimProvider{}
+	_ provider.ProviderWithEphemeralResources = &BluestonePimProvider{}
+	_ provider.ProviderWithActions            = &BluestonePimProvider{}
+)
 
 // BluestonePimProvider defines the provider implementation.
 type BluestonePimProvider struct {
@@ -40,6 +48,8 @@ type BluestonePimProviderModel struct {
 	ClientSecret types.String `tfsdk:"client_secret"`
 	AuthURL      types.String `tfsdk:"auth_url"`
 	ApiURL       types.String `tfsdk:"api_url"`
+
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
 }
 
 func (p *BluestonePimProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -67,6 +77,12 @@ func (p *BluestonePimProvider) Schema(ctx context.Context, req provider.SchemaRe
 				MarkdownDescription: "The api URL of the Bluestone Platform API",
 				Optional:            true,
 			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "The default of the `deletion_protection` attribute of all resources. " +
+					"When enabled, resources can only be deleted after setting their `deletion_protection` " +
+					"to `false`. Defaults to `false`.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -132,6 +148,10 @@ func (p *BluestonePimProvider) Configure(ctx context.Context, req provider.Confi
 		context.WithValue(context.Background(), oauth2.HTTPClient, retryableClient.StandardClient()),
 	)
 
+	// Share the responses of read requests between all resources
+	readCache := utils.NewReadCache()
+	httpClient.Transport = readCache.Transport(httpClient.Transport)
+
 	pimClient, err := pim.NewClientWithResponses(
 		fmt.Sprintf("%s/pim", apiURL),
 		pim.WithHTTPClient(httpClient),
@@ -157,22 +177,29 @@ func (p *BluestonePimProvider) Configure(ctx context.Context, req provider.Confi
 	}
 
 	container := &utils.ProviderData{
-		PimClient:            pimClient,
-		NotificationClient:   notificationsClient,
-		GlobalSettingsClient: globalSettingsClient,
+		PimClient:                pimClient,
+		NotificationClient:       notificationsClient,
+		GlobalSettingsClient:     globalSettingsClient,
+		ContextFallbacks:         utils.NewContextFallbacks(),
+		CategoryReads:            category.NewReadBatcher(pimClient),
+		AttributeDefinitionReads: attribute_definition.NewReadBatcher(pimClient),
+		DeletionProtection:       data.DeletionProtection.ValueBool(),
 	}
 
 	// Example client configuration for data sources and resources
 	resp.DataSourceData = container
 	resp.ResourceData = container
+	resp.ActionData = container
 }
 
 func (p *BluestonePimProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		category.NewResource,
 		attribute_definition.NewResource,
+		attribute_enum_value.NewResource,
 		category_attribute.NewResource,
 		webhook.NewResource,
+		webhook_subscription.NewResource,
 		bpcontext.NewResource,
 	}
 }
@@ -180,6 +207,26 @@ func (p *BluestonePimProvider) Resources(ctx context.Context) []func() resource.
 func (p *BluestonePimProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		category.NewDataSource,
+		category.NewPathDataSource,
+		webhook.NewDataSource,
+		webhook.NewListDataSource,
+		webhook.NewEventTypesDataSource,
+		bpcontext.NewDataSource,
+		bpcontext.NewListDataSource,
+		attribute_definition.NewUsageDataSource,
+		category_attribute.NewSchemaDataSource,
+	}
+}
+
+func (p *BluestonePimProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		webhook.NewSecretEphemeralResource,
+	}
+}
+
+func (p *BluestonePimProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		webhook.NewTestDeliveryAction,
 	}
 }
 
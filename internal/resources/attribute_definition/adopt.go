@@ -0,0 +1,167 @@
+package attribute_definition
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/labd/bluestonepim-go-sdk/pim"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// The ways Bluestone PIM checks for duplicate attribute definitions.
+const (
+	DuplicateValidationName = "name"
+	DuplicateValidationNone = "none"
+)
+
+// validation returns the validation mode sent to Bluestone PIM, which
+// defaults to validating the name.
+func (a *AttributeDefinition) validation() string {
+	if a.DuplicateValidation.ValueString() == DuplicateValidationNone {
+		return strings.ToUpper(DuplicateValidationNone)
+	}
+	return strings.ToUpper(DuplicateValidationName)
+}
+
+// findAttributeDefinitions returns the attribute definitions matching the
+// filters, or all attribute definitions when there are no filters.
+func findAttributeDefinitions(
+	ctx context.Context,
+	client pim.ClientWithResponsesInterface,
+	filters *[]pim.AttributeDefinitionFilterDto,
+) iter.Seq2[pim.AttributeDefinitionResponse, diag.Diagnostic] {
+	return utils.Paginate(ctx, utils.DefaultPageSize, func(ctx context.Context, page utils.Page) ([]pim.AttributeDefinitionResponse, diag.Diagnostic) {
+		res, err := client.FindFilteredAttributeDefinitionsWithResponse(ctx, nil, pim.FindFilteredAttributeDefinitionsJSONRequestBody{
+			Filters:  filters,
+			Page:     utils.Ref(int32(page.Number)),
+			PageSize: utils.Ref(int32(page.Size)),
+		})
+		if err != nil {
+			return nil, diag.NewErrorDiagnostic("Unable to find attribute definition", err.Error())
+		}
+		if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+			return nil, d
+		}
+		return utils.Items(res.JSON200.Data), nil
+	})
+}
+
+// findExistingAttributeDefinition returns the attribute definition adopted
+// by the planned attribute definition, matched by number when it is set and
+// by name otherwise. It returns nil when there is no match.
+func findExistingAttributeDefinition(
+	ctx context.Context,
+	client pim.ClientWithResponsesInterface,
+	resource *AttributeDefinition,
+) (*pim.AttributeDefinitionResponse, diag.Diagnostic) {
+	var filters *[]pim.AttributeDefinitionFilterDto
+	var match func(pim.AttributeDefinitionResponse) bool
+	var key string
+	switch {
+	case !resource.Number.IsNull() && !resource.Number.IsUnknown():
+		number := resource.Number.ValueString()
+		filters = &[]pim.AttributeDefinitionFilterDto{
+			{
+				Type:   utils.Ref(pim.AttributeDefinitionFilterDtoTypeNUMBERIN),
+				Values: &[]string{number},
+			},
+		}
+		match = func(d pim.AttributeDefinitionResponse) bool { return d.Number != nil && *d.Number == number }
+		key = fmt.Sprintf("number %q", number)
+	case !resource.Name.IsNull() && !resource.Name.IsUnknown():
+		name := resource.Name.ValueString()
+		match = func(d pim.AttributeDefinitionResponse) bool { return d.Name == name }
+		key = fmt.Sprintf("name %q", name)
+	default:
+		return nil, nil
+	}
+
+	var found []pim.AttributeDefinitionResponse
+	for definition, d := range findAttributeDefinitions(ctx, client, filters) {
+		if d != nil {
+			return nil, d
+		}
+		if match(definition) {
+			found = append(found, definition)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, diag.NewErrorDiagnostic(
+			"Cannot adopt attribute definition",
+			fmt.Sprintf("%d attribute definitions with %s exist. Set the number of the attribute definition "+
+				"to adopt, or import it instead.", len(found), key),
+		)
+	}
+}
+
+// adoptAttributeDefinition updates the existing attribute definition to the
+// planned values, so it is managed by the resource instead of creating a new
+// one. onCreated is called with its id before it is updated.
+func adoptAttributeDefinition(
+	ctx context.Context,
+	client pim.ClientWithResponsesInterface,
+	existing *pim.AttributeDefinitionResponse,
+	planned *AttributeDefinition,
+	onCreated utils.OnCreated,
+) (*AttributeDefinition, diag.Diagnostic) {
+	current := toAttributeDefinition(existing)
+	if !current.DataType.Equal(planned.DataType) {
+		return nil, diag.NewErrorDiagnostic(
+			"Cannot adopt attribute definition",
+			fmt.Sprintf("Attribute definition %s (%s) has data type %s, but %s is configured. The data type "+
+				"cannot be changed, so it cannot be adopted.", current.Id.ValueString(), current.Name.ValueString(),
+				current.DataType.ValueString(), planned.DataType.ValueString()),
+		)
+	}
+	if d := onCreated(current.Id.ValueString()); d != nil {
+		return nil, d
+	}
+
+	adopted := *planned
+	adopted.Id = current.Id
+	if adopted.Number.IsUnknown() {
+		adopted.Number = current.Number
+	}
+	if adopted.ContentType.IsUnknown() {
+		adopted.ContentType = current.ContentType
+	}
+	adopted.Restrictions, current.Restrictions = adoptEnumValues(current.Restrictions, planned.Restrictions)
+
+	return UpdateAttributeDefinition(ctx, client, current, &adopted)
+}
+
+// adoptEnumValues returns the planned restrictions with the ids of the
+// existing enum values with the same number, so they are updated in place.
+// It also returns the existing restrictions with only the planned enum
+// values, so values that are not configured are kept when the values are not
+// exclusive.
+func adoptEnumValues(existing *Restrictions, planned *Restrictions) (*Restrictions, *Restrictions) {
+	if existing == nil || existing.Enum == nil || planned == nil || planned.Enum == nil {
+		return planned, existing
+	}
+
+	adopted, managed := *planned, *existing
+	adoptedEnum, managedEnum := *planned.Enum, *existing.Enum
+	adoptedEnum.Values = make(map[string]EnumValue, len(planned.Enum.Values))
+	managedEnum.Values = make(map[string]EnumValue, len(planned.Enum.Values))
+	for number, value := range planned.Enum.Values {
+		if current, ok := existing.Enum.Values[number]; ok {
+			value.ValueId = current.ValueId
+			managedEnum.Values[number] = current
+		}
+		adoptedEnum.Values[number] = value
+	}
+	adopted.Enum, managed.Enum = &adoptedEnum, &managedEnum
+	return &adopted, &managed
+}
@@ -2,17 +2,34 @@ package attribute_definition
 
 import (
 	"context"
+	"fmt"
 	"github.com/labd/bluestonepim-go-sdk/pim"
 	"net/http"
 	"reflect"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
 )
 
-func GetAttributeDefinitionByID(ctx context.Context, client pim.ClientWithResponsesInterface, id string) (*AttributeDefinition, diag.Diagnostic) {
+// definitionLocks serializes the read-modify-write updates of the enum values
+// of an attribute definition, keyed by attribute definition id.
+var definitionLocks sync.Map
+
+// LockAttributeDefinition locks the attribute definition for a read-modify-
+// write update and returns the function to unlock it.
+func LockAttributeDefinition(id string) func() {
+	mu, _ := definitionLocks.LoadOrStore(id, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// GetAttributeDefinitionResponse returns the attribute definition as returned
+// by the API.
+func GetAttributeDefinitionResponse(ctx context.Context, client pim.ClientWithResponsesInterface, id string) (*pim.AttributeDefinitionResponse, diag.Diagnostic) {
 	resp, err := client.GetAttributeDefinitionWithResponse(ctx, id, nil)
 
 	if err != nil {
@@ -23,9 +40,103 @@ func GetAttributeDefinitionByID(ctx context.Context, client pim.ClientWithRespon
 		return nil, d
 	}
 
-	resource := resp.JSON200
+	return resp.JSON200, nil
+}
+
+// UpdateEnumValues replaces the enum values of the attribute definition,
+// keeping all other settings as returned by the API.
+func UpdateEnumValues(ctx context.Context, client pim.ClientWithResponsesInterface, resource *pim.AttributeDefinitionResponse, values []pim.SelectAttributeValueDto) diag.Diagnostic {
+	if resource.Restrictions == nil || resource.Restrictions.Enum == nil {
+		return diag.NewErrorDiagnostic(
+			"Attribute definition has no enum values",
+			fmt.Sprintf("Attribute definition %s is not a select attribute", *resource.Id),
+		)
+	}
+
+	restrictions := *resource.Restrictions
+	enum := *restrictions.Enum
+	enum.Values = &values
+	restrictions.Enum = &enum
+
+	var dataType *pim.SimpleAttributeDefinitionRequestDataType
+	if resource.DataType != nil {
+		dataType = utils.Ref(pim.SimpleAttributeDefinitionRequestDataType(*resource.DataType))
+	}
+
+	res, err := client.UpdateAttributeDefinitionWithResponse(ctx, *resource.Id, nil,
+		pim.UpdateAttributeDefinitionJSONRequestBody{
+			Charset:        resource.Charset,
+			ContentType:    resource.ContentType,
+			DataType:       dataType,
+			ExternalSource: resource.ExternalSource,
+			Internal:       resource.Internal,
+			GroupId:        resource.GroupId,
+			Name:           resource.Name,
+			Number:         resource.Number,
+			Unit:           resource.Unit,
+			Restrictions:   &restrictions,
+		})
+	if err != nil {
+		return diag.NewErrorDiagnostic("Unable to update attribute definition", err.Error())
+	}
 
-	result := &AttributeDefinition{
+	return utils.AssertStatusCode(res, http.StatusOK)
+}
+
+func GetAttributeDefinitionByID(ctx context.Context, client pim.ClientWithResponsesInterface, id string) (*AttributeDefinition, diag.Diagnostic) {
+	resource, d := GetAttributeDefinitionResponse(ctx, client, id)
+	if d != nil {
+		return nil, d
+	}
+	return toAttributeDefinition(resource), nil
+}
+
+// LoadAttributeDefinitionByID reads the attribute definition together with
+// the other attribute definitions read at the same time. Attribute
+// definitions that are not returned in bulk are read on their own.
+func LoadAttributeDefinitionByID(ctx context.Context, client pim.ClientWithResponsesInterface, reads *utils.Batcher[pim.AttributeDefinitionResponse], id string) (*AttributeDefinition, diag.Diagnostic) {
+	if reads != nil {
+		resource, found, d := reads.Load(ctx, id)
+		if d != nil {
+			tflog.Debug(ctx, "Reading attribute definitions in bulk failed", map[string]any{"error": d.Detail()})
+		} else if found {
+			return toAttributeDefinition(resource), nil
+		}
+	}
+	return GetAttributeDefinitionByID(ctx, client, id)
+}
+
+// NewReadBatcher returns a batcher reading attribute definitions in bulk.
+func NewReadBatcher(client pim.ClientWithResponsesInterface) *utils.Batcher[pim.AttributeDefinitionResponse] {
+	return utils.NewBatcher(utils.ReadBatchWait, utils.ReadBatchSize, func(ctx context.Context, ids []string) (map[string]pim.AttributeDefinitionResponse, diag.Diagnostic) {
+		res, err := client.FindFilteredAttributeDefinitionsWithResponse(ctx, nil, pim.FindFilteredAttributeDefinitionsJSONRequestBody{
+			Filters: &[]pim.AttributeDefinitionFilterDto{
+				{
+					Type:   utils.Ref(pim.AttributeDefinitionFilterDtoTypeIDIN),
+					Values: &ids,
+				},
+			},
+			PageSize: utils.Ref(int32(len(ids))),
+		})
+		if err != nil {
+			return nil, diag.NewErrorDiagnostic("Unable to read attribute definitions", err.Error())
+		}
+		if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+			return nil, d
+		}
+
+		result := make(map[string]pim.AttributeDefinitionResponse, len(ids))
+		for _, definition := range utils.Items(res.JSON200.Data) {
+			if definition.Id != nil && definition.DataType != nil {
+				result[*definition.Id] = definition
+			}
+		}
+		return result, nil
+	})
+}
+
+func toAttributeDefinition(resource *pim.AttributeDefinitionResponse) *AttributeDefinition {
+	return &AttributeDefinition{
 		Id:             types.StringPointerValue(resource.Id),
 		Name:           types.StringValue(resource.Name),
 		Description:    types.StringPointerValue(resource.Description),
@@ -38,8 +149,11 @@ func GetAttributeDefinitionByID(ctx context.Context, client pim.ClientWithRespon
 		GroupID:        types.StringPointerValue(resource.GroupId),
 		Unit:           types.StringPointerValue(resource.Unit),
 		Restrictions:   FromRestrictionsDto(resource.Restrictions),
+
+		PreventDestroyWhenInUse: types.BoolValue(false),
+		AdoptExisting:           types.BoolValue(false),
+		DuplicateValidation:     types.StringValue(DuplicateValidationName),
 	}
-	return result, nil
 }
 
 func FromRestrictionsDto(restrictions *pim.RestrictionsDto) *Restrictions {
@@ -49,19 +163,29 @@ func FromRestrictionsDto(restrictions *pim.RestrictionsDto) *Restrictions {
 
 	var dto = &Restrictions{}
 	if restrictions.Enum != nil {
-		values := make([]EnumValue, 0, len(*restrictions.Enum.Values))
-		for _, v := range *restrictions.Enum.Values {
-			values = append(values, EnumValue{
-				Metadata: types.StringPointerValue(v.Metadata),
-				Number:   types.StringPointerValue(v.Number),
-				Value:    types.StringValue(v.Value),
-				ValueId:  types.StringPointerValue(v.ValueId),
-			})
+		var values map[string]EnumValue
+		if restrictions.Enum.Values != nil {
+			values = make(map[string]EnumValue, len(*restrictions.Enum.Values))
+			for _, v := range *restrictions.Enum.Values {
+				// Values without a number cannot be managed, they are
+				// kept as they are on update
+				number := enumValueKey(v)
+				if number == "" {
+					continue
+				}
+				values[number] = EnumValue{
+					Metadata: types.StringPointerValue(v.Metadata),
+					Value:    types.StringValue(v.Value),
+					ValueId:  types.StringPointerValue(v.ValueId),
+				}
+			}
 		}
 
 		dto.Enum = &EnumRestriction{
 			Type:   types.StringPointerValue(restrictions.Enum.Type),
-			Values: &values,
+			Values: values,
+			// Not returned by the API, the resource keeps the configured value
+			ExclusiveValues: types.BoolValue(true),
 		}
 	}
 	if restrictions.Range != nil {
@@ -85,14 +209,49 @@ func FromRestrictionsDto(restrictions *pim.RestrictionsDto) *Restrictions {
 	return dto
 }
 
-func CreateAttributeDefinition(ctx context.Context, client pim.ClientWithResponsesInterface, resource *AttributeDefinition) (*AttributeDefinition, diag.Diagnostic) {
+// enumValueKey returns the key of the enum value in the values map, which is
+// its number. Values created outside of Terraform may lack a number, for
+// these an empty key is returned.
+func enumValueKey(v pim.SelectAttributeValueDto) string {
+	if v.Number == nil {
+		return ""
+	}
+	return *v.Number
+}
+
+// CreateAttributeDefinition creates the attribute definition and sets its
+// description, which cannot be set on create. onCreated is called with the id
+// before the description is set. When AdoptExisting is set and a matching
+// attribute definition exists, it is adopted instead.
+func CreateAttributeDefinition(
+	ctx context.Context,
+	client pim.ClientWithResponsesInterface,
+	resource *AttributeDefinition,
+	onCreated utils.OnCreated,
+) (*AttributeDefinition, diag.Diagnostic) {
+	if resource.AdoptExisting.ValueBool() {
+		existing, d := findExistingAttributeDefinition(ctx, client, resource)
+		if d != nil {
+			return nil, d
+		}
+		if existing != nil {
+			return adoptAttributeDefinition(ctx, client, existing, resource, onCreated)
+		}
+	}
+
+	// The content type is unknown for data types without a content type
+	var contentType *string
+	if !resource.ContentType.IsUnknown() {
+		contentType = resource.ContentType.ValueStringPointer()
+	}
+
 	resC, err := client.CreateAttributeDefinitionWithResponse(ctx,
 		&pim.CreateAttributeDefinitionParams{
-			Validation: utils.Ref[pim.CreateAttributeDefinitionParamsValidation]("NAME"),
+			Validation: utils.Ref(pim.CreateAttributeDefinitionParamsValidation(resource.validation())),
 		},
 		pim.CreateAttributeDefinitionJSONRequestBody{
 			Charset:        resource.CharacterSet.ValueStringPointer(),
-			ContentType:    resource.ContentType.ValueStringPointer(),
+			ContentType:    contentType,
 			DataType:       utils.Ref(pim.SimpleAttributeDefinitionRequestDataType(resource.DataType.ValueString())),
 			ExternalSource: resource.ExternalSource.ValueBoolPointer(),
 			Internal:       resource.Internal.ValueBoolPointer(),
@@ -113,9 +272,20 @@ func CreateAttributeDefinition(ctx context.Context, client pim.ClientWithRespons
 	}
 
 	resourceId := resC.HTTPResponse.Header.Get("Resource-Id")
+	if d := onCreated(resourceId); d != nil {
+		return nil, d
+	}
+
+	// New attribute definitions are not always found right away
+	if d := waitForAttributeDefinition(ctx, client, resourceId, true); d != nil {
+		return nil, d
+	}
 
 	//Workaround because we cannot set description on create
-	resU, err := client.UpdateMetadataWithResponse(ctx, resourceId, nil,
+	resU, err := client.UpdateMetadataWithResponse(ctx, resourceId,
+		&pim.UpdateMetadataParams{
+			Validation: utils.Ref(pim.UpdateMetadataParamsValidation(resource.validation())),
+		},
 		pim.UpdateMetadataJSONRequestBody{
 			Description: &pim.PropertyUpdateString{
 				Value: resource.Description.ValueStringPointer(),
@@ -151,7 +321,28 @@ func attributeDefinitionHasChanges(current *AttributeDefinition, planned *Attrib
 
 func UpdateAttributeDefinition(ctx context.Context, client pim.ClientWithResponsesInterface, current *AttributeDefinition, planned *AttributeDefinition) (*AttributeDefinition, diag.Diagnostic) {
 	if attributeDefinitionHasChanges(current, planned) {
-		res, err := client.UpdateAttributeDefinitionWithResponse(ctx, current.Id.ValueString(), nil,
+		restrictions := ToRestrictionsDto(planned.Restrictions)
+
+		// Keep the enum values without a number and the values that are
+		// managed elsewhere, for example with bluestonepim_attribute_enum_value.
+		// The values are read past the cache, as they may have been changed
+		// since the refresh.
+		if planned.Restrictions != nil && planned.Restrictions.Enum != nil {
+			unlock := LockAttributeDefinition(current.Id.ValueString())
+			defer unlock()
+
+			resource, d := GetAttributeDefinitionResponse(utils.WithFreshReads(ctx), client, current.Id.ValueString())
+			if d != nil {
+				return nil, d
+			}
+			values := mergeEnumValues(resource.Restrictions, *restrictions.Enum.Values, current, planned)
+			restrictions.Enum.Values = &values
+		}
+
+		res, err := client.UpdateAttributeDefinitionWithResponse(ctx, current.Id.ValueString(),
+			&pim.UpdateAttributeDefinitionParams{
+				Validation: utils.Ref(pim.UpdateAttributeDefinitionParamsValidation(planned.validation())),
+			},
 			pim.UpdateAttributeDefinitionJSONRequestBody{
 				Charset:        planned.CharacterSet.ValueStringPointer(),
 				ContentType:    planned.ContentType.ValueStringPointer(),
@@ -162,7 +353,7 @@ func UpdateAttributeDefinition(ctx context.Context, client pim.ClientWithRespons
 				Name:           planned.Name.ValueString(),
 				Number:         planned.Number.ValueStringPointer(),
 				Unit:           planned.Unit.ValueStringPointer(),
-				Restrictions:   ToRestrictionsDto(planned.Restrictions),
+				Restrictions:   restrictions,
 			})
 		if err != nil {
 			return nil, diag.NewErrorDiagnostic("Unable to update attribute definition", err.Error())
@@ -175,7 +366,10 @@ func UpdateAttributeDefinition(ctx context.Context, client pim.ClientWithRespons
 
 	if !planned.Description.Equal(current.Description) {
 		//Workaround because we cannot set description on create
-		resU, err := client.UpdateMetadataWithResponse(ctx, current.Id.ValueString(), nil,
+		resU, err := client.UpdateMetadataWithResponse(ctx, current.Id.ValueString(),
+			&pim.UpdateMetadataParams{
+				Validation: utils.Ref(pim.UpdateMetadataParamsValidation(planned.validation())),
+			},
 			pim.UpdateMetadataJSONRequestBody{
 				Description: &pim.PropertyUpdateString{
 					Value: planned.Description.ValueStringPointer(),
@@ -192,6 +386,57 @@ func UpdateAttributeDefinition(ctx context.Context, client pim.ClientWithRespons
 	return GetAttributeDefinitionByID(ctx, client, current.Id.ValueString())
 }
 
+// mergeEnumValues returns the planned values together with the enum values
+// without a number, and when the planned values are not exclusive, the values
+// that are neither in the current nor in the planned values. The values keep
+// their order in Bluestone PIM, new planned values are added at the end.
+func mergeEnumValues(
+	restrictions *pim.RestrictionsDto,
+	plannedValues []pim.SelectAttributeValueDto,
+	current *AttributeDefinition,
+	planned *AttributeDefinition,
+) []pim.SelectAttributeValueDto {
+	byNumber := make(map[string]pim.SelectAttributeValueDto, len(plannedValues))
+	for _, v := range plannedValues {
+		byNumber[enumValueKey(v)] = v
+	}
+
+	managed := func(r *Restrictions, number string) bool {
+		if r == nil || r.Enum == nil {
+			return false
+		}
+		_, ok := r.Enum.Values[number]
+		return ok
+	}
+
+	values := make([]pim.SelectAttributeValueDto, 0, len(plannedValues))
+	added := map[string]bool{}
+	if restrictions != nil && restrictions.Enum != nil {
+		for _, v := range utils.Items(restrictions.Enum.Values) {
+			number := enumValueKey(v)
+			if p, ok := byNumber[number]; number != "" && ok {
+				if !added[number] {
+					values = append(values, p)
+					added[number] = true
+				}
+				continue
+			}
+			if number != "" && (planned.Restrictions.Enum.isExclusive() ||
+				managed(current.Restrictions, number) || managed(planned.Restrictions, number)) {
+				continue
+			}
+			values = append(values, v)
+		}
+	}
+
+	for _, v := range plannedValues {
+		if !added[enumValueKey(v)] {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
 func DeleteAttributeDefinition(ctx context.Context, client pim.ClientWithResponsesInterface, id string) diag.Diagnostic {
 	response, err := client.DeleteAttributeDefinitionWithResponse(ctx, id)
 	if err != nil {
@@ -202,5 +447,33 @@ func DeleteAttributeDefinition(ctx context.Context, client pim.ClientWithRespons
 		return d
 	}
 
+	// The deletion is processed asynchronously
+	if d := waitForAttributeDefinition(ctx, client, id, false); d != nil {
+		return d
+	}
+
 	return nil
 }
+
+// waitForAttributeDefinition waits until the attribute definition is found,
+// or until it is no longer found when exists is false.
+func waitForAttributeDefinition(ctx context.Context, client pim.ClientWithResponsesInterface, id string, exists bool) diag.Diagnostic {
+	description := fmt.Sprintf("attribute definition %s to be created", id)
+	if !exists {
+		description = fmt.Sprintf("attribute definition %s to be deleted", id)
+	}
+
+	return utils.WaitFor(ctx, description, func(ctx context.Context) (bool, diag.Diagnostic) {
+		res, err := client.GetAttributeDefinitionWithResponse(ctx, id, nil)
+		if err != nil {
+			return false, diag.NewErrorDiagnostic("Unable to read data", err.Error())
+		}
+		if utils.IsNotFound(res) {
+			return !exists, nil
+		}
+		if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+			return false, d
+		}
+		return exists, nil
+	})
+}
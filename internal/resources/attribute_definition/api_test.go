@@ -0,0 +1,70 @@
+package attribute_definition
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/bluestonepim-go-sdk/pim"
+)
+
+func enumValueDto(number, value string) pim.SelectAttributeValueDto {
+	v := pim.SelectAttributeValueDto{Value: value}
+	if number != "" {
+		v.Number = &number
+	}
+	return v
+}
+
+func enumDefinition(exclusive bool, numbers ...string) *AttributeDefinition {
+	values := map[string]EnumValue{}
+	for _, number := range numbers {
+		values[number] = EnumValue{Value: types.StringValue(number)}
+	}
+	return &AttributeDefinition{Restrictions: &Restrictions{Enum: &EnumRestriction{
+		Values:          values,
+		ExclusiveValues: types.BoolValue(exclusive),
+	}}}
+}
+
+func TestMergeEnumValuesKeepsExistingOrder(t *testing.T) {
+	existing := &pim.RestrictionsDto{Enum: &pim.SelectRestrictionsDto{Values: &[]pim.SelectAttributeValueDto{
+		enumValueDto("10", "ten"),
+		enumValueDto("", "unnumbered"),
+		enumValueDto("other", "managed elsewhere"),
+		enumValueDto("2", "two"),
+		enumValueDto("3", "removed"),
+	}}}
+	current := enumDefinition(false, "10", "2", "3")
+	planned := enumDefinition(false, "10", "2", "20", "4")
+	plannedValues := ToRestrictionsDto(planned.Restrictions).Enum.Values
+
+	var numbers []string
+	for _, v := range mergeEnumValues(existing, *plannedValues, current, planned) {
+		numbers = append(numbers, enumValueKey(v)+":"+v.Value)
+	}
+
+	expected := []string{"10:10", ":unnumbered", "other:managed elsewhere", "2:2", "4:4", "20:20"}
+	if !slices.Equal(numbers, expected) {
+		t.Errorf("expected %v, got %v", expected, numbers)
+	}
+}
+
+func TestMergeEnumValuesDropsUnplannedValuesWhenExclusive(t *testing.T) {
+	existing := &pim.RestrictionsDto{Enum: &pim.SelectRestrictionsDto{Values: &[]pim.SelectAttributeValueDto{
+		enumValueDto("other", "other"),
+		enumValueDto("", "unnumbered"),
+		enumValueDto("1", "one"),
+	}}}
+	planned := enumDefinition(true, "1")
+	plannedValues := ToRestrictionsDto(planned.Restrictions).Enum.Values
+
+	var numbers []string
+	for _, v := range mergeEnumValues(existing, *plannedValues, enumDefinition(true), planned) {
+		numbers = append(numbers, enumValueKey(v))
+	}
+
+	if !slices.Equal(numbers, []string{"", "1"}) {
+		t.Errorf("expected the unnumbered and planned value, got %v", numbers)
+	}
+}
@@ -1,8 +1,17 @@
 package attribute_definition
 
 import (
+	"cmp"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/labd/bluestonepim-go-sdk/pim"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
 )
 
 type AttributeDefinition struct {
@@ -18,6 +27,12 @@ type AttributeDefinition struct {
 	Internal       types.Bool    `tfsdk:"internal"`
 	Unit           types.String  `tfsdk:"unit"`
 	Restrictions   *Restrictions `tfsdk:"restrictions"`
+
+	PreventDestroyWhenInUse types.Bool     `tfsdk:"prevent_destroy_when_in_use"`
+	AdoptExisting           types.Bool     `tfsdk:"adopt_existing"`
+	DuplicateValidation     types.String   `tfsdk:"duplicate_validation"`
+	DeletionProtection      types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
 }
 
 type Restrictions struct {
@@ -27,13 +42,20 @@ type Restrictions struct {
 }
 
 func ToRestrictionsDto(r *Restrictions) *pim.RestrictionsDto {
-	if r == nil {
+	if r == nil || (r.Enum == nil && r.Range == nil && r.Text == nil) {
 		return nil
 	}
 
+	dto := &pim.RestrictionsDto{}
 	if r.Enum != nil {
-		var values []pim.SelectAttributeValueDto
-		for _, v := range *r.Enum.Values {
+		// Values are sent ordered by number, so the request is stable. The
+		// order of existing values is kept on update, see mergeEnumValues.
+		values := make([]pim.SelectAttributeValueDto, 0, len(r.Enum.Values))
+		for _, number := range slices.SortedFunc(maps.Keys(r.Enum.Values), compareEnumNumbers) {
+			v := r.Enum.Values[number]
+
+			// The value id is kept in the state, so the existing value is
+			// updated instead of replaced by a new one
 			var valueId *string
 			if !v.ValueId.IsNull() && !v.ValueId.IsUnknown() {
 				valueId = v.ValueId.ValueStringPointer()
@@ -41,51 +63,107 @@ func ToRestrictionsDto(r *Restrictions) *pim.RestrictionsDto {
 
 			values = append(values, pim.SelectAttributeValueDto{
 				Metadata: v.Metadata.ValueStringPointer(),
-				Number:   v.Number.ValueStringPointer(),
+				Number:   utils.Ref(number),
 				Value:    v.Value.ValueString(),
 				ValueId:  valueId,
 			})
 		}
 
-		return &pim.RestrictionsDto{
-			Enum: &pim.SelectRestrictionsDto{
-				Type:   r.Enum.Type.ValueStringPointer(),
-				Values: &values,
-			},
+		dto.Enum = &pim.SelectRestrictionsDto{
+			Type:   r.Enum.Type.ValueStringPointer(),
+			Values: &values,
 		}
 	}
 
 	if r.Range != nil {
-		return &pim.RestrictionsDto{
-			Range: &pim.RangeRestrictionsDto{
-				Max:  r.Range.Max.ValueStringPointer(),
-				Min:  r.Range.Min.ValueStringPointer(),
-				Step: r.Range.Step.ValueStringPointer(),
-			},
+		dto.Range = &pim.RangeRestrictionsDto{
+			Max:  r.Range.Max.ValueStringPointer(),
+			Min:  r.Range.Min.ValueStringPointer(),
+			Step: r.Range.Step.ValueStringPointer(),
 		}
 	}
 
 	if r.Text != nil {
-		return &pim.RestrictionsDto{
-			Text: &pim.TextRestrictionsDto{
-				MaxLength:   r.Text.MaxLength.ValueInt32Pointer(),
-				Pattern:     r.Text.Pattern.ValueStringPointer(),
-				Whitespaces: r.Text.Whitespaces.ValueBoolPointer(),
-			},
+		dto.Text = &pim.TextRestrictionsDto{
+			MaxLength:   r.Text.MaxLength.ValueInt32Pointer(),
+			Pattern:     r.Text.Pattern.ValueStringPointer(),
+			Whitespaces: r.Text.Whitespaces.ValueBoolPointer(),
 		}
 	}
 
-	return nil
+	return dto
+}
+
+// compareEnumNumbers orders enum value numbers numerically when both are
+// integers, so "2" comes before "10", and as text otherwise.
+func compareEnumNumbers(a, b string) int {
+	x, errA := strconv.ParseInt(a, 10, 64)
+	y, errB := strconv.ParseInt(b, 10, 64)
+	if errA == nil && errB == nil && x != y {
+		return cmp.Compare(x, y)
+	}
+	return strings.Compare(a, b)
 }
 
 type EnumRestriction struct {
-	Type   types.String `tfsdk:"type"`
-	Values *[]EnumValue `tfsdk:"values"`
+	Type types.String `tfsdk:"type"`
+	// Values are keyed by their number
+	Values          map[string]EnumValue `tfsdk:"values"`
+	ExclusiveValues types.Bool           `tfsdk:"exclusive_values"`
+}
+
+// isExclusive reports whether the values are the complete list of values of
+// the enum. Null is treated as exclusive, the default.
+func (e *EnumRestriction) isExclusive() bool {
+	return e.ExclusiveValues.IsNull() || e.ExclusiveValues.ValueBool()
+}
+
+// keepLocalSettings copies the settings that are not stored in Bluestone PIM
+// from source. When the enum values are not exclusive, only the values that
+// are managed in source are kept.
+func (a *AttributeDefinition) keepLocalSettings(source *AttributeDefinition) {
+	a.DeletionProtection = source.DeletionProtection
+	a.Timeouts = source.Timeouts
+	a.PreventDestroyWhenInUse = source.PreventDestroyWhenInUse
+	if a.PreventDestroyWhenInUse.IsNull() {
+		a.PreventDestroyWhenInUse = types.BoolValue(false)
+	}
+	a.AdoptExisting = source.AdoptExisting
+	if a.AdoptExisting.IsNull() {
+		a.AdoptExisting = types.BoolValue(false)
+	}
+	a.DuplicateValidation = source.DuplicateValidation
+	if a.DuplicateValidation.IsNull() {
+		a.DuplicateValidation = types.StringValue(DuplicateValidationName)
+	}
+
+	if a.Restrictions == nil || a.Restrictions.Enum == nil || source.Restrictions == nil || source.Restrictions.Enum == nil {
+		return
+	}
+
+	enum, sourceEnum := a.Restrictions.Enum, source.Restrictions.Enum
+	if !sourceEnum.ExclusiveValues.IsNull() {
+		enum.ExclusiveValues = sourceEnum.ExclusiveValues
+	}
+	if enum.isExclusive() {
+		return
+	}
+
+	var values map[string]EnumValue
+	for number, v := range enum.Values {
+		if _, ok := sourceEnum.Values[number]; !ok {
+			continue
+		}
+		if values == nil {
+			values = map[string]EnumValue{}
+		}
+		values[number] = v
+	}
+	enum.Values = values
 }
 
 type EnumValue struct {
 	Metadata types.String `tfsdk:"metadata"`
-	Number   types.String `tfsdk:"number"`
 	Value    types.String `tfsdk:"value"`
 	ValueId  types.String `tfsdk:"value_id"`
 }
@@ -101,3 +179,23 @@ type TextRestriction struct {
 	Pattern     types.String `tfsdk:"pattern"`
 	Whitespaces types.Bool   `tfsdk:"whitespaces"`
 }
+
+// AttributeUsage describes the usage data source data model.
+type AttributeUsage struct {
+	ID           types.String    `tfsdk:"id"`
+	Number       types.String    `tfsdk:"number"`
+	Name         types.String    `tfsdk:"name"`
+	Categories   []CategoryUsage `tfsdk:"categories"`
+	ProductCount types.Int64     `tfsdk:"product_count"`
+}
+
+// CategoryUsage is a category the attribute definition is assigned on or
+// inherited into.
+type CategoryUsage struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Number     types.String `tfsdk:"number"`
+	AssignedOn types.String `tfsdk:"assigned_on"`
+	Inherited  types.Bool   `tfsdk:"inherited"`
+	Mandatory  types.Bool   `tfsdk:"mandatory"`
+}
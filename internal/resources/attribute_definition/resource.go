@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/labd/bluestonepim-go-sdk/pim"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -19,9 +20,10 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &Resource{}
-	_ resource.ResourceWithConfigure   = &Resource{}
-	_ resource.ResourceWithImportState = &Resource{}
+	_ resource.Resource                 = &Resource{}
+	_ resource.ResourceWithConfigure    = &Resource{}
+	_ resource.ResourceWithImportState  = &Resource{}
+	_ resource.ResourceWithUpgradeState = &Resource{}
 )
 
 func NewResource() resource.Resource {
@@ -29,7 +31,9 @@ func NewResource() resource.Resource {
 }
 
 type Resource struct {
-	client pim.ClientWithResponsesInterface
+	client             pim.ClientWithResponsesInterface
+	reads              *utils.Batcher[pim.AttributeDefinitionResponse]
+	deletionProtection bool
 }
 
 // Metadata returns the data source type name.
@@ -38,8 +42,9 @@ func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, res
 }
 
 // Schema defines the schema for the data source.
-func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *Resource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             1,
 		MarkdownDescription: "",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -79,13 +84,13 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 				},
 			},
 			"content_type": schema.StringAttribute{
-				MarkdownDescription: "The content type of the attribute.",
-				Optional:            true,
-				Computed:            true,
+				MarkdownDescription: "The content type of the attribute. Only applies to the `text`, " +
+					"`formatted_text` and `multiline` data types, where it defaults to `text/markdown`.",
+				Optional: true,
+				Computed: true,
 				Validators: []validator.String{
 					stringvalidator.OneOf("text/markdown", "html"),
 				},
-				Default: stringdefault.StaticString("text/markdown"),
 			},
 			"character_set": schema.StringAttribute{
 				MarkdownDescription: "The unit of the attribute.",
@@ -111,9 +116,42 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 				MarkdownDescription: "The unit of the attribute.",
 				Optional:            true,
 			},
+			"prevent_destroy_when_in_use": schema.BoolAttribute{
+				MarkdownDescription: "Whether to fail the plan when the attribute definition is deleted or " +
+					"replaced while categories or products still use it. Replacing the attribute definition, for " +
+					"example when the `data_type` changes, removes the values of all products. When `false` a " +
+					"warning with the number of categories and products using it is shown instead.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to adopt an existing attribute definition on create instead of " +
+					"failing. It is matched by `number` when set, and by `name` otherwise. The adopted attribute " +
+					"definition is updated to the configured values and managed by this resource. Its " +
+					"`data_type` must match the configured one.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"duplicate_validation": schema.StringAttribute{
+				MarkdownDescription: "How Bluestone PIM checks for duplicates when the attribute definition is " +
+					"created or updated. `name` rejects a name that is already used by another attribute " +
+					"definition, `none` allows duplicate names. Defaults to `name`. Categories are always " +
+					"checked by name.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(DuplicateValidationName),
+				Validators: []validator.String{
+					stringvalidator.OneOf(DuplicateValidationName, DuplicateValidationNone),
+				},
+			},
 			"restrictions": schema.SingleNestedAttribute{
-				MarkdownDescription: "The restrictions of the attribute.",
-				Optional:            true,
+				MarkdownDescription: "The restrictions of the attribute. `enum` applies to the `single_select` " +
+					"and `multi_select` data types, `range` to the `integer`, `decimal`, `date`, `time` and " +
+					"`date_time` data types, and `text` to the `text`, `formatted_text`, `pattern` and " +
+					"`multiline` data types.",
+				Optional: true,
 				Attributes: map[string]schema.Attribute{
 					"enum": schema.SingleNestedAttribute{
 						Optional: true,
@@ -127,18 +165,32 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 									stringvalidator.OneOf("text", "color"),
 								},
 							},
-							"values": schema.ListNestedAttribute{
+							"exclusive_values": schema.BoolAttribute{
+								MarkdownDescription: "Whether `values` is the complete list of values of the enum. " +
+									"When `true`, values that are not in `values` are removed. Set to `false` when " +
+									"values are also managed elsewhere, for example with " +
+									"`bluestonepim_attribute_enum_value`, so only the values listed here are managed.",
 								Optional: true,
+								Computed: true,
+								Default:  booldefault.StaticBool(true),
+							},
+							"values": schema.MapNestedAttribute{
+								MarkdownDescription: "The values of the enum, keyed by their number. Values are " +
+									"matched by number, so changing the label of a value updates it in place " +
+									"and keeps its `value_id` and the product data assigned to it. Values without " +
+									"a number, for example created in Bluestone PIM, are not managed and are kept " +
+									"as they are. Values keep their order in Bluestone PIM, new values are added " +
+									"at the end ordered by number.",
+								Optional: true,
+								Validators: []validator.Map{
+									mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+								},
 								NestedObject: schema.NestedAttributeObject{
 									Attributes: map[string]schema.Attribute{
 										"metadata": schema.StringAttribute{
 											MarkdownDescription: "The metadata of the enum.",
 											Optional:            true,
 										},
-										"number": schema.StringAttribute{
-											MarkdownDescription: "The number of the enum.",
-											Optional:            true,
-										},
 										"value": schema.StringAttribute{
 											MarkdownDescription: "The value of the enum.",
 											Required:            true,
@@ -146,6 +198,9 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 										"value_id": schema.StringAttribute{
 											MarkdownDescription: "The ID of the value.",
 											Computed:            true,
+											PlanModifiers: []planmodifier.String{
+												stringplanmodifier.UseStateForUnknown(),
+											},
 										},
 									},
 								},
@@ -188,6 +243,10 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 					},
 				},
 			},
+			"deletion_protection": utils.DeletionProtectionAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": utils.TimeoutsBlock(ctx),
 		},
 	}
 }
@@ -205,6 +264,8 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, r
 	}
 
 	r.client = data.PimClient
+	r.reads = data.AttributeDefinitionReads
+	r.deletionProtection = data.DeletionProtection
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -216,11 +277,20 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
-	result, diag := CreateAttributeDefinition(ctx, r.client, &plan)
+	timeout, diags := plan.Timeouts.Create(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, diag := CreateAttributeDefinition(ctx, r.client, &plan, utils.SaveCreatedID(ctx, &resp.State, plan, path.Root("id")))
 	if diag != nil {
 		resp.Diagnostics.Append(diag)
 		return
 	}
+	result.keepLocalSettings(&plan)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, result)
@@ -239,11 +309,12 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
-	result, diag := GetAttributeDefinitionByID(ctx, r.client, current.Id.ValueString())
+	result, diag := LoadAttributeDefinitionByID(ctx, r.client, r.reads, current.Id.ValueString())
 	if diag != nil {
 		resp.Diagnostics.Append(diag)
 		return
 	}
+	result.keepLocalSettings(&current)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, result)
@@ -263,6 +334,14 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	timeout, diags := plan.Timeouts.Update(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	// Retrieve values from state
 	var state AttributeDefinition
 	diags = req.State.Get(ctx, &state)
@@ -276,6 +355,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		resp.Diagnostics.Append(diag)
 		return
 	}
+	result.keepLocalSettings(&plan)
 
 	diags = resp.State.Set(ctx, result)
 	resp.Diagnostics.Append(diags...)
@@ -294,6 +374,19 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
+	timeout, diags := state.Timeouts.Delete(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if d := utils.CheckDeletionProtection(state.DeletionProtection, r.deletionProtection, "attribute definition "+state.Id.ValueString()); d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+
 	diag := DeleteAttributeDefinition(ctx, r.client, state.Id.ValueString())
 	if diag != nil {
 		resp.Diagnostics.Append(diag)
@@ -0,0 +1,168 @@
+package attribute_definition
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// AttributeDefinitionV0 is the state model of schema version 0, where the
+// enum values were a list.
+type AttributeDefinitionV0 struct {
+	Id             types.String    `tfsdk:"id"`
+	Name           types.String    `tfsdk:"name"`
+	Number         types.String    `tfsdk:"number"`
+	Description    types.String    `tfsdk:"description"`
+	DataType       types.String    `tfsdk:"data_type"`
+	ContentType    types.String    `tfsdk:"content_type"`
+	CharacterSet   types.String    `tfsdk:"character_set"`
+	ExternalSource types.Bool      `tfsdk:"external_source"`
+	GroupID        types.String    `tfsdk:"group_id"`
+	Internal       types.Bool      `tfsdk:"internal"`
+	Unit           types.String    `tfsdk:"unit"`
+	Restrictions   *RestrictionsV0 `tfsdk:"restrictions"`
+}
+
+type RestrictionsV0 struct {
+	Enum  *EnumRestrictionV0 `tfsdk:"enum"`
+	Range *RangeRestriction  `tfsdk:"range"`
+	Text  *TextRestriction   `tfsdk:"text"`
+}
+
+type EnumRestrictionV0 struct {
+	Type   types.String   `tfsdk:"type"`
+	Values *[]EnumValueV0 `tfsdk:"values"`
+}
+
+type EnumValueV0 struct {
+	Metadata types.String `tfsdk:"metadata"`
+	Number   types.String `tfsdk:"number"`
+	Value    types.String `tfsdk:"value"`
+	ValueId  types.String `tfsdk:"value_id"`
+}
+
+var schemaV0 = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"id":              schema.StringAttribute{Computed: true},
+		"number":          schema.StringAttribute{Optional: true, Computed: true},
+		"name":            schema.StringAttribute{Optional: true},
+		"description":     schema.StringAttribute{Optional: true},
+		"data_type":       schema.StringAttribute{Required: true},
+		"content_type":    schema.StringAttribute{Optional: true, Computed: true},
+		"character_set":   schema.StringAttribute{Optional: true},
+		"external_source": schema.BoolAttribute{Optional: true, Computed: true},
+		"internal":        schema.BoolAttribute{Optional: true, Computed: true},
+		"group_id":        schema.StringAttribute{Optional: true},
+		"unit":            schema.StringAttribute{Optional: true},
+		"restrictions": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"enum": schema.SingleNestedAttribute{
+					Optional: true,
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{Optional: true, Computed: true},
+						"values": schema.ListNestedAttribute{
+							Optional: true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"metadata": schema.StringAttribute{Optional: true},
+									"number":   schema.StringAttribute{Optional: true},
+									"value":    schema.StringAttribute{Required: true},
+									"value_id": schema.StringAttribute{Computed: true},
+								},
+							},
+						},
+					},
+				},
+				"range": schema.SingleNestedAttribute{
+					Optional: true,
+					Attributes: map[string]schema.Attribute{
+						"max":  schema.StringAttribute{Optional: true},
+						"min":  schema.StringAttribute{Optional: true},
+						"step": schema.StringAttribute{Optional: true},
+					},
+				},
+				"text": schema.SingleNestedAttribute{
+					Optional: true,
+					Attributes: map[string]schema.Attribute{
+						"max_length":  schema.Int32Attribute{Optional: true},
+						"pattern":     schema.StringAttribute{Optional: true},
+						"whitespaces": schema.BoolAttribute{Optional: true},
+					},
+				},
+			},
+		},
+	},
+}
+
+// UpgradeState converts the enum values list of schema version 0 to a map
+// keyed by number.
+func (r *Resource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior AttributeDefinitionV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := AttributeDefinition{
+					Id:             prior.Id,
+					Name:           prior.Name,
+					Number:         prior.Number,
+					Description:    prior.Description,
+					DataType:       prior.DataType,
+					ContentType:    prior.ContentType,
+					CharacterSet:   prior.CharacterSet,
+					ExternalSource: prior.ExternalSource,
+					GroupID:        prior.GroupID,
+					Internal:       prior.Internal,
+					Unit:           prior.Unit,
+
+					PreventDestroyWhenInUse: types.BoolValue(false),
+					AdoptExisting:           types.BoolValue(false),
+					DuplicateValidation:     types.StringValue(DuplicateValidationName),
+					Timeouts:                utils.NullTimeouts(),
+				}
+
+				if prior.Restrictions != nil {
+					upgraded.Restrictions = &Restrictions{
+						Range: prior.Restrictions.Range,
+						Text:  prior.Restrictions.Text,
+					}
+
+					if prior.Restrictions.Enum != nil {
+						enum := &EnumRestriction{
+							Type:            prior.Restrictions.Enum.Type,
+							ExclusiveValues: types.BoolValue(true),
+						}
+						if prior.Restrictions.Enum.Values != nil {
+							enum.Values = make(map[string]EnumValue, len(*prior.Restrictions.Enum.Values))
+							for _, v := range *prior.Restrictions.Enum.Values {
+								// Values without a number are no longer
+								// managed, they are kept as they are
+								if v.Number.ValueString() == "" {
+									continue
+								}
+								enum.Values[v.Number.ValueString()] = EnumValue{
+									Metadata: v.Metadata,
+									Value:    v.Value,
+									ValueId:  v.ValueId,
+								}
+							}
+						}
+						upgraded.Restrictions.Enum = enum
+					}
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+			},
+		},
+	}
+}
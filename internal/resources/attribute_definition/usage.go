@@ -0,0 +1,240 @@
+package attribute_definition
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/bluestonepim-go-sdk/pim"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/resources/category"
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// GetCategoriesUsingAttributeDefinition returns the categories the attribute
+// definition is assigned to.
+func GetCategoriesUsingAttributeDefinition(ctx context.Context, client pim.ClientWithResponsesInterface, id string) ([]pim.CategoryBasicResponse, diag.Diagnostic) {
+	return utils.CollectPages(ctx, utils.DefaultPageSize, func(ctx context.Context, page utils.Page) ([]pim.CategoryBasicResponse, diag.Diagnostic) {
+		res, err := client.FindByCategoryLevelAttributesWithResponse(ctx, id, &pim.FindByCategoryLevelAttributesParams{
+			Page:     utils.Ref(int32(page.Number)),
+			PageSize: utils.Ref(int32(page.Size)),
+		})
+		if err != nil {
+			return nil, diag.NewErrorDiagnostic("Unable to list categories using attribute definition", err.Error())
+		}
+		if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+			return nil, d
+		}
+		return utils.Items(res.JSON200.Data), nil
+	})
+}
+
+// CountProductsUsingAttributeDefinition returns the number of products with a
+// value for the attribute definition.
+func CountProductsUsingAttributeDefinition(ctx context.Context, client pim.ClientWithResponsesInterface, id string) (int64, diag.Diagnostic) {
+	res, err := client.CountFilteredProductsWithResponse(ctx, nil, pim.CountFilteredProductsJSONRequestBody{
+		AttributeFilters: &[]pim.GridAttributeFilterDto{
+			{
+				AttributeDefinitionId: utils.Ref(id),
+				Type:                  pim.GridAttributeFilterDtoTypeNOTEMPTY,
+			},
+		},
+	})
+	if err != nil {
+		return 0, diag.NewErrorDiagnostic("Unable to count products using attribute definition", err.Error())
+	}
+	if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+		return 0, d
+	}
+
+	if res.JSON200.Count == nil {
+		return 0, nil
+	}
+	return *res.JSON200.Count, nil
+}
+
+// GetAttributeUsage returns the categories the attribute definition is
+// assigned on or inherited into and the number of products with a value for
+// it. The attribute definition is looked up by id or, when id is empty, by
+// number.
+func GetAttributeUsage(ctx context.Context, client pim.ClientWithResponsesInterface, id, number string) (*AttributeUsage, diag.Diagnostic) {
+	var definition *pim.AttributeDefinitionResponse
+	var d diag.Diagnostic
+	if id != "" {
+		definition, d = GetAttributeDefinitionResponse(ctx, client, id)
+	} else {
+		definition, d = findAttributeDefinitionByNumber(ctx, client, number)
+	}
+	if d != nil {
+		return nil, d
+	}
+	if definition.Id != nil {
+		id = *definition.Id
+	}
+
+	assigned, d := GetCategoriesUsingAttributeDefinition(ctx, client, id)
+	if d != nil {
+		return nil, d
+	}
+
+	// The attribute is inherited by all descendants of the categories it is
+	// assigned on
+	categories := slices.DeleteFunc(slices.Clone(assigned), func(c pim.CategoryBasicResponse) bool { return c.Id == nil })
+	seen := map[string]bool{}
+	for _, c := range categories {
+		seen[*c.Id] = true
+	}
+	for i := 0; i < len(categories); i++ {
+		children, d := category.ListCategoryChildren(ctx, client, *categories[i].Id)
+		if d != nil {
+			return nil, d
+		}
+		for _, child := range children {
+			if child.Id == nil || seen[*child.Id] {
+				continue
+			}
+			seen[*child.Id] = true
+			categories = append(categories, child)
+		}
+	}
+
+	result := &AttributeUsage{
+		ID:         types.StringValue(id),
+		Number:     types.StringPointerValue(definition.Number),
+		Name:       types.StringValue(definition.Name),
+		Categories: make([]CategoryUsage, 0, len(categories)),
+	}
+	for _, c := range categories {
+		usage, d := getCategoryUsage(ctx, client, c, id)
+		if d != nil {
+			return nil, d
+		}
+		if usage != nil {
+			result.Categories = append(result.Categories, *usage)
+		}
+	}
+
+	products, d := CountProductsUsingAttributeDefinition(ctx, client, id)
+	if d != nil {
+		return nil, d
+	}
+	result.ProductCount = types.Int64Value(products)
+
+	return result, nil
+}
+
+// getCategoryUsage returns how the attribute definition is used on the
+// category, or nil when the category does not have the attribute.
+func getCategoryUsage(ctx context.Context, client pim.ClientWithResponsesInterface, node pim.CategoryBasicResponse, id string) (*CategoryUsage, diag.Diagnostic) {
+	attributes, d := category.ListCategoryAttributes(ctx, client, *node.Id)
+	if d != nil {
+		return nil, d
+	}
+
+	for _, attribute := range attributes {
+		if attribute.AttributeDefinitionId == nil || *attribute.AttributeDefinitionId != id {
+			continue
+		}
+
+		assignedOn := *node.Id
+		if attribute.AssignedOn != nil {
+			assignedOn = *attribute.AssignedOn
+		}
+		return &CategoryUsage{
+			ID:         types.StringValue(*node.Id),
+			Name:       types.StringPointerValue(node.Name),
+			Number:     types.StringPointerValue(node.Number),
+			AssignedOn: types.StringValue(assignedOn),
+			Inherited:  types.BoolValue(assignedOn != *node.Id),
+			Mandatory:  types.BoolValue(attribute.MandatorySetOn != nil),
+		}, nil
+	}
+	return nil, nil
+}
+
+// findAttributeDefinitionByNumber returns the attribute definition with the
+// given number.
+func findAttributeDefinitionByNumber(ctx context.Context, client pim.ClientWithResponsesInterface, number string) (*pim.AttributeDefinitionResponse, diag.Diagnostic) {
+	definitions := findAttributeDefinitions(ctx, client, &[]pim.AttributeDefinitionFilterDto{
+		{
+			Type:   utils.Ref(pim.AttributeDefinitionFilterDtoTypeNUMBERIN),
+			Values: &[]string{number},
+		},
+	})
+
+	for definition, d := range definitions {
+		if d != nil {
+			return nil, d
+		}
+		if definition.Number != nil && *definition.Number == number {
+			return &definition, nil
+		}
+	}
+	return nil, diag.NewErrorDiagnostic(
+		"Attribute definition not found",
+		fmt.Sprintf("No attribute definition with number %q exists.", number),
+	)
+}
+
+// checkUsage adds a warning with the number of categories and products using
+// the attribute definition when it is destroyed or replaced, as this removes
+// the attribute from the categories and the values from the products. The
+// warning is an error when prevent_destroy_when_in_use is set.
+func (r *Resource) checkUsage(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var state AttributeDefinition
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	action := "Deleting"
+	prevent := state.PreventDestroyWhenInUse
+	if !req.Plan.Raw.IsNull() {
+		var dataType types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("data_type"), &dataType)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("prevent_destroy_when_in_use"), &prevent)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Only a change of the data type replaces the attribute definition
+		if dataType.IsUnknown() || dataType.Equal(state.DataType) {
+			return
+		}
+		action = "Replacing"
+	}
+
+	// The provider is not configured yet, so the usage can not be looked up
+	if r.client == nil {
+		return
+	}
+
+	id := state.Id.ValueString()
+	categories, d := GetCategoriesUsingAttributeDefinition(ctx, r.client, id)
+	if d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+	products, d := CountProductsUsingAttributeDefinition(ctx, r.client, id)
+	if d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+	if len(categories) == 0 && products == 0 {
+		return
+	}
+
+	summary := "Attribute definition in use"
+	detail := fmt.Sprintf("%s attribute definition %q removes it from %d categories and deletes its values "+
+		"from %d products.", action, state.Name.ValueString(), len(categories), products)
+	if prevent.ValueBool() {
+		resp.Diagnostics.AddError(summary, detail+" Set prevent_destroy_when_in_use to false to allow this.")
+		return
+	}
+	resp.Diagnostics.AddWarning(summary, detail)
+}
@@ -0,0 +1,127 @@
+package attribute_definition
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/labd/bluestonepim-go-sdk/pim"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UsageDataSource{}
+
+func NewUsageDataSource() datasource.DataSource {
+	return &UsageDataSource{}
+}
+
+// UsageDataSource defines the attribute usage data source implementation.
+type UsageDataSource struct {
+	client pim.ClientWithResponsesInterface
+}
+
+func (d *UsageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_attribute_usage"
+}
+
+func (d *UsageDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads where an attribute definition is used: the categories it is assigned on or " +
+			"inherited into and the number of products with a value for it. Use it to check the impact " +
+			"before removing a `bluestonepim_category_attribute` or an attribute definition.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the attribute definition. Exactly one of `id` or `number` must be set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("number")),
+				},
+			},
+			"number": schema.StringAttribute{
+				MarkdownDescription: "The number of the attribute definition.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the attribute definition.",
+				Computed:            true,
+			},
+			"categories": schema.ListNestedAttribute{
+				MarkdownDescription: "The categories the attribute definition is assigned on, followed by the " +
+					"categories that inherit it.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the category.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the category.",
+							Computed:            true,
+						},
+						"number": schema.StringAttribute{
+							MarkdownDescription: "The number of the category.",
+							Computed:            true,
+						},
+						"assigned_on": schema.StringAttribute{
+							MarkdownDescription: "The ID of the category the attribute definition is assigned on.",
+							Computed:            true,
+						},
+						"inherited": schema.BoolAttribute{
+							MarkdownDescription: "Whether the attribute definition is inherited from a parent category.",
+							Computed:            true,
+						},
+						"mandatory": schema.BoolAttribute{
+							MarkdownDescription: "Whether the attribute is mandatory in the category.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"product_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of products with a value for the attribute definition.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *UsageDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, diag := utils.GetProviderData(req.ProviderData)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	d.client = data.PimClient
+}
+
+func (d *UsageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AttributeUsage
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, diag := GetAttributeUsage(ctx, d.client, data.ID.ValueString(), data.Number.ValueString())
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
+}
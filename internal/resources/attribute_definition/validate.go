@@ -0,0 +1,351 @@
+package attribute_definition
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/labd/bluestonepim-go-sdk/pim"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.ResourceWithValidateConfig = &Resource{}
+	_ resource.ResourceWithModifyPlan     = &Resource{}
+)
+
+// defaultContentType is the content type of text-like attributes when none is
+// configured.
+const defaultContentType = "text/markdown"
+
+// textDataTypes are the data types that have a content type.
+var textDataTypes = []string{"text", "formatted_text", "multiline"}
+
+// allowedRestrictions lists the restrictions each data type supports. Data
+// types that are not listed support no restrictions.
+var allowedRestrictions = map[string][]string{
+	"single_select":  {"enum"},
+	"multi_select":   {"enum"},
+	"integer":        {"range"},
+	"decimal":        {"range"},
+	"date":           {"range"},
+	"time":           {"range"},
+	"date_time":      {"range"},
+	"text":           {"text"},
+	"formatted_text": {"text"},
+	"pattern":        {"text"},
+	"multiline":      {"text"},
+}
+
+// ValidateConfig checks that the restrictions and content type match the data
+// type of the attribute.
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var dataType, contentType types.String
+	var restrictionsObject types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("data_type"), &dataType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content_type"), &contentType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("restrictions"), &restrictionsObject)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if dataType.IsNull() || dataType.IsUnknown() {
+		return
+	}
+
+	if !contentType.IsNull() && !contentType.IsUnknown() && !slices.Contains(textDataTypes, dataType.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("content_type"),
+			"Content type not supported",
+			fmt.Sprintf("The content type only applies to the %s data types, not to %s.",
+				strings.Join(textDataTypes, ", "), dataType.ValueString()),
+		)
+	}
+
+	if restrictionsObject.IsNull() || restrictionsObject.IsUnknown() {
+		return
+	}
+
+	var restrictions Restrictions
+	resp.Diagnostics.Append(restrictionsObject.As(ctx, &restrictions, basetypes.ObjectAsOptions{
+		UnhandledUnknownAsEmpty: true,
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateRestrictions(dataType.ValueString(), &restrictions, resp)
+}
+
+// validateRestrictions checks that the restrictions are supported by the data
+// type and that their values are valid.
+func validateRestrictions(dataType string, restrictions *Restrictions, resp *resource.ValidateConfigResponse) {
+	restrictionsPath := path.Root("restrictions")
+	for _, restriction := range []struct {
+		name    string
+		present bool
+	}{
+		{"enum", restrictions.Enum != nil},
+		{"range", restrictions.Range != nil},
+		{"text", restrictions.Text != nil},
+	} {
+		if name := restriction.name; restriction.present && !slices.Contains(allowedRestrictions[dataType], name) {
+			resp.Diagnostics.AddAttributeError(
+				restrictionsPath.AtName(name),
+				"Restriction not supported",
+				fmt.Sprintf("The %s restriction can not be used with the %s data type.", name, dataType),
+			)
+		}
+	}
+
+	if restrictions.Range != nil {
+		validateRange(dataType, restrictions.Range, restrictionsPath.AtName("range"), resp)
+	}
+
+	if restrictions.Text != nil {
+		pattern := restrictions.Text.Pattern
+		// Bluestone PIM uses Java regular expressions, which support syntax
+		// such as lookaheads that RE2 does not, so this is only a warning
+		if !pattern.IsNull() && !pattern.IsUnknown() {
+			if _, err := regexp.Compile(pattern.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeWarning(
+					restrictionsPath.AtName("text").AtName("pattern"),
+					"Pattern not checked",
+					fmt.Sprintf("The pattern could not be checked, it is not a valid RE2 regular expression: %s. "+
+						"Bluestone PIM uses Java regular expressions, so it is only rejected on apply when it "+
+						"is not a valid Java regular expression either.", err),
+				)
+			}
+		}
+	}
+}
+
+// validateRange checks that min, max and step parse as the data type and that
+// min is not larger than max.
+func validateRange(dataType string, r *RangeRestriction, rangePath path.Path, resp *resource.ValidateConfigResponse) {
+	parse, ok := rangeParsers[dataType]
+	if !ok {
+		return
+	}
+
+	values := map[string]*big.Float{}
+	for _, bound := range []struct {
+		name  string
+		value types.String
+	}{
+		{"min", r.Min},
+		{"max", r.Max},
+	} {
+		name, value := bound.name, bound.value
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		parsed, err := parse(value.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				rangePath.AtName(name),
+				"Invalid range value",
+				fmt.Sprintf("The value %q is not a valid %s: %s", value.ValueString(), dataType, err),
+			)
+			continue
+		}
+		values[name] = parsed
+	}
+
+	if values["min"] != nil && values["max"] != nil && values["min"].Cmp(values["max"]) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			rangePath.AtName("min"),
+			"Invalid range",
+			fmt.Sprintf("The minimum %s is larger than the maximum %s.", r.Min.ValueString(), r.Max.ValueString()),
+		)
+	}
+
+	// The unit of the step of date and time values is not defined, so only
+	// numeric steps are checked
+	if (dataType == "integer" || dataType == "decimal") && !r.Step.IsNull() && !r.Step.IsUnknown() {
+		parseStep := parseDecimal
+		if dataType == "integer" {
+			parseStep = parseInteger
+		}
+		step, err := parseStep(r.Step.ValueString())
+		if err == nil && step.Sign() <= 0 {
+			err = fmt.Errorf("must be larger than zero")
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				rangePath.AtName("step"),
+				"Invalid range step",
+				fmt.Sprintf("The step %q is not valid: %s", r.Step.ValueString(), err),
+			)
+		}
+	}
+}
+
+// rangeParsers parse a range value of a data type into a comparable number.
+var rangeParsers = map[string]func(string) (*big.Float, error){
+	"integer":   parseInteger,
+	"decimal":   parseDecimal,
+	"date":      parseTime(time.DateOnly),
+	"time":      parseTime(time.TimeOnly),
+	"date_time": parseTime(time.RFC3339),
+}
+
+func parseInteger(value string) (*big.Float, error) {
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("expected an integer")
+	}
+	return new(big.Float).SetInt64(i), nil
+}
+
+func parseDecimal(value string) (*big.Float, error) {
+	f, _, err := big.ParseFloat(value, 10, 128, big.ToNearestEven)
+	if err != nil {
+		return nil, fmt.Errorf("expected a decimal number")
+	}
+	return f, nil
+}
+
+func parseTime(layout string) func(string) (*big.Float, error) {
+	return func(value string) (*big.Float, error) {
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			return nil, fmt.Errorf("expected the format %s", layout)
+		}
+		return new(big.Float).SetInt64(t.UnixNano()), nil
+	}
+}
+
+// ValidateValue checks that value is valid for an attribute with the data
+// type and restrictions, for example as the default value of a category
+// attribute. A single_select value must be the value id, number or value of
+// one of the enum values, a multi_select value a comma separated list of
+// them. Text values must match the whole pattern. Values of other data
+// types, such as location, are not checked.
+func ValidateValue(dataType string, restrictions *pim.RestrictionsDto, value string) error {
+	switch {
+	case dataType == "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("expected true or false")
+		}
+	case rangeParsers[dataType] != nil:
+		parse := rangeParsers[dataType]
+		parsed, err := parse(value)
+		if err != nil {
+			return err
+		}
+		if restrictions != nil && restrictions.Range != nil {
+			if lower := restrictions.Range.Min; lower != nil {
+				if bound, err := parse(*lower); err == nil && parsed.Cmp(bound) < 0 {
+					return fmt.Errorf("must be at least %s", *lower)
+				}
+			}
+			if upper := restrictions.Range.Max; upper != nil {
+				if bound, err := parse(*upper); err == nil && parsed.Cmp(bound) > 0 {
+					return fmt.Errorf("must be at most %s", *upper)
+				}
+			}
+		}
+	case slices.Contains(textDataTypes, dataType) || dataType == "pattern":
+		if restrictions != nil && restrictions.Text != nil {
+			if maxLength := restrictions.Text.MaxLength; maxLength != nil && utf8.RuneCountInString(value) > int(*maxLength) {
+				return fmt.Errorf("must be at most %d characters", *maxLength)
+			}
+			if pattern := restrictions.Text.Pattern; pattern != nil {
+				if re, err := regexp.Compile("^(?:" + *pattern + ")$"); err == nil && !re.MatchString(value) {
+					return fmt.Errorf("must match the pattern %s", *pattern)
+				}
+			}
+		}
+	case dataType == "single_select":
+		return validateSelectValues(restrictions, []string{value})
+	case dataType == "multi_select":
+		values := strings.Split(value, ",")
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+		return validateSelectValues(restrictions, values)
+	}
+	return nil
+}
+
+// validateSelectValues checks that each value is the value id, number or
+// value of one of the enum values. Enums without values are not checked, as
+// their values may be added by bluestonepim_attribute_enum_value resources.
+func validateSelectValues(restrictions *pim.RestrictionsDto, values []string) error {
+	if restrictions == nil || restrictions.Enum == nil || restrictions.Enum.Values == nil {
+		return nil
+	}
+
+	enum := *restrictions.Enum.Values
+	for _, value := range values {
+		found := slices.ContainsFunc(enum, func(v pim.SelectAttributeValueDto) bool {
+			return (v.ValueId != nil && *v.ValueId == value) || (v.Number != nil && *v.Number == value) || v.Value == value
+		})
+		if !found {
+			allowed := make([]string, 0, len(enum))
+			for _, v := range enum {
+				allowed = append(allowed, v.Value)
+			}
+			return fmt.Errorf("%q must be one of the enum values: %s", value, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+// ModifyPlan defaults the content type of text-like attributes. Other data
+// types have no content type, so the value returned by the API is kept. It
+// also reports the usage of attribute definitions that are destroyed or
+// replaced.
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.State.Raw.IsNull() {
+		r.checkUsage(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Nothing to plan when the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var dataType, contentType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("data_type"), &dataType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("content_type"), &contentType)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !contentType.IsNull() || dataType.IsUnknown() {
+		return
+	}
+
+	if slices.Contains(textDataTypes, dataType.ValueString()) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_type"), defaultContentType)...)
+		return
+	}
+
+	// data_type requires replacement, so the prior content type belongs to
+	// the same data type
+	if !req.State.Raw.IsNull() {
+		var stateDataType, stateContentType types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("data_type"), &stateDataType)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("content_type"), &stateContentType)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if stateDataType.Equal(dataType) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_type"), stateContentType)...)
+		}
+	}
+}
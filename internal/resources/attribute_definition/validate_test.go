@@ -0,0 +1,44 @@
+package attribute_definition
+
+import (
+	"testing"
+
+	"github.com/labd/bluestonepim-go-sdk/pim"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+func TestValidateValueMatchesWholePattern(t *testing.T) {
+	restrictions := &pim.RestrictionsDto{Text: &pim.TextRestrictionsDto{Pattern: utils.Ref("[0-9]+")}}
+
+	if err := ValidateValue("text", restrictions, "123"); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := ValidateValue("text", restrictions, "abc1"); err == nil {
+		t.Error("expected a value of which only a substring matches to be rejected")
+	}
+}
+
+func TestValidateValueChecksSelectValues(t *testing.T) {
+	restrictions := &pim.RestrictionsDto{Enum: &pim.SelectRestrictionsDto{Values: &[]pim.SelectAttributeValueDto{
+		{Number: utils.Ref("1"), Value: "red", ValueId: utils.Ref("a")},
+		{Number: utils.Ref("2"), Value: "blue", ValueId: utils.Ref("b")},
+	}}}
+
+	for _, test := range []struct {
+		dataType string
+		value    string
+		valid    bool
+	}{
+		{"single_select", "a", true},
+		{"single_select", "2", true},
+		{"single_select", "green", false},
+		{"multi_select", "a, blue", true},
+		{"multi_select", "a,green", false},
+	} {
+		err := ValidateValue(test.dataType, restrictions, test.value)
+		if (err == nil) != test.valid {
+			t.Errorf("%s %q: expected valid %v, got error %v", test.dataType, test.value, test.valid, err)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package attribute_enum_value
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/bluestonepim-go-sdk/pim"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/resources/attribute_definition"
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// GetEnumValue returns the enum value with the number of the given value, or
+// nil when the attribute definition has no such value.
+func GetEnumValue(
+	ctx context.Context,
+	client pim.ClientWithResponsesInterface,
+	current *AttributeEnumValue,
+) (*AttributeEnumValue, diag.Diagnostic) {
+	resource, d := attribute_definition.GetAttributeDefinitionResponse(ctx, client, current.AttributeDefinitionID.ValueString())
+	if d != nil {
+		return nil, d
+	}
+
+	values := enumValues(resource)
+	i := indexOf(values, current.Number.ValueString())
+	if i < 0 {
+		return nil, nil
+	}
+
+	return &AttributeEnumValue{
+		AttributeDefinitionID: current.AttributeDefinitionID,
+		Number:                current.Number,
+		Value:                 types.StringValue(values[i].Value),
+		Metadata:              types.StringPointerValue(values[i].Metadata),
+		ValueID:               types.StringPointerValue(values[i].ValueId),
+	}, nil
+}
+
+func CreateEnumValue(
+	ctx context.Context,
+	client pim.ClientWithResponsesInterface,
+	planned *AttributeEnumValue,
+) (*AttributeEnumValue, diag.Diagnostic) {
+	d := modifyEnumValues(ctx, client, planned, func(values []pim.SelectAttributeValueDto) ([]pim.SelectAttributeValueDto, diag.Diagnostic) {
+		if indexOf(values, planned.Number.ValueString()) >= 0 {
+			return nil, diag.NewErrorDiagnostic(
+				"Enum value already exists",
+				fmt.Sprintf("Attribute definition %s already has a value with number %s, import it instead",
+					planned.AttributeDefinitionID.ValueString(), planned.Number.ValueString()),
+			)
+		}
+
+		return append(values, pim.SelectAttributeValueDto{
+			Metadata: planned.Metadata.ValueStringPointer(),
+			Number:   planned.Number.ValueStringPointer(),
+			Value:    planned.Value.ValueString(),
+		}), nil
+	})
+	if d != nil {
+		return nil, d
+	}
+
+	return getExistingEnumValue(ctx, client, planned)
+}
+
+func UpdateEnumValue(
+	ctx context.Context,
+	client pim.ClientWithResponsesInterface,
+	planned *AttributeEnumValue,
+) (*AttributeEnumValue, diag.Diagnostic) {
+	d := modifyEnumValues(ctx, client, planned, func(values []pim.SelectAttributeValueDto) ([]pim.SelectAttributeValueDto, diag.Diagnostic) {
+		i := indexOf(values, planned.Number.ValueString())
+		if i < 0 {
+			return nil, notFound(planned)
+		}
+
+		// The value id is kept, so product data assigned to the value survives
+		values[i].Value = planned.Value.ValueString()
+		values[i].Metadata = planned.Metadata.ValueStringPointer()
+		return values, nil
+	})
+	if d != nil {
+		return nil, d
+	}
+
+	return getExistingEnumValue(ctx, client, planned)
+}
+
+func DeleteEnumValue(
+	ctx context.Context,
+	client pim.ClientWithResponsesInterface,
+	current *AttributeEnumValue,
+) diag.Diagnostic {
+	return modifyEnumValues(ctx, client, current, func(values []pim.SelectAttributeValueDto) ([]pim.SelectAttributeValueDto, diag.Diagnostic) {
+		i := indexOf(values, current.Number.ValueString())
+		if i < 0 {
+			// Already removed
+			return nil, nil
+		}
+		return append(values[:i], values[i+1:]...), nil
+	})
+}
+
+// modifyEnumValues reads the enum values of the attribute definition, applies
+// modify to them and writes them back, preserving the values that are not
+// modified. The values are read past the cache, as the lock only covers the
+// changes made by this provider. A nil result of modify leaves the values
+// untouched.
+func modifyEnumValues(
+	ctx context.Context,
+	client pim.ClientWithResponsesInterface,
+	value *AttributeEnumValue,
+	modify func([]pim.SelectAttributeValueDto) ([]pim.SelectAttributeValueDto, diag.Diagnostic),
+) diag.Diagnostic {
+	id := value.AttributeDefinitionID.ValueString()
+
+	unlock := attribute_definition.LockAttributeDefinition(id)
+	defer unlock()
+
+	resource, d := attribute_definition.GetAttributeDefinitionResponse(utils.WithFreshReads(ctx), client, id)
+	if d != nil {
+		return d
+	}
+
+	values, d := modify(enumValues(resource))
+	if d != nil || values == nil {
+		return d
+	}
+
+	return attribute_definition.UpdateEnumValues(ctx, client, resource, values)
+}
+
+// getExistingEnumValue returns the enum value, failing when it does not exist.
+func getExistingEnumValue(
+	ctx context.Context,
+	client pim.ClientWithResponsesInterface,
+	value *AttributeEnumValue,
+) (*AttributeEnumValue, diag.Diagnostic) {
+	result, d := GetEnumValue(ctx, client, value)
+	if d != nil {
+		return nil, d
+	}
+	if result == nil {
+		return nil, notFound(value)
+	}
+	return result, nil
+}
+
+func enumValues(resource *pim.AttributeDefinitionResponse) []pim.SelectAttributeValueDto {
+	if resource.Restrictions == nil || resource.Restrictions.Enum == nil || resource.Restrictions.Enum.Values == nil {
+		return []pim.SelectAttributeValueDto{}
+	}
+	return *resource.Restrictions.Enum.Values
+}
+
+func indexOf(values []pim.SelectAttributeValueDto, number string) int {
+	for i, v := range values {
+		if v.Number != nil && *v.Number == number {
+			return i
+		}
+	}
+	return -1
+}
+
+func notFound(value *AttributeEnumValue) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Enum value not found",
+		fmt.Sprintf("Attribute definition %s has no value with number %s",
+			value.AttributeDefinitionID.ValueString(), value.Number.ValueString()),
+	)
+}
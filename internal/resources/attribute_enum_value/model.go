@@ -0,0 +1,24 @@
+package attribute_enum_value
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type AttributeEnumValue struct {
+	AttributeDefinitionID types.String `tfsdk:"attribute_definition_id"`
+	Number                types.String `tfsdk:"number"`
+	Value                 types.String `tfsdk:"value"`
+	Metadata              types.String `tfsdk:"metadata"`
+	ValueID               types.String `tfsdk:"value_id"`
+
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// keepLocalSettings copies the settings that are not stored in Bluestone PIM
+// from source.
+func (v *AttributeEnumValue) keepLocalSettings(source *AttributeEnumValue) {
+	v.DeletionProtection = source.DeletionProtection
+	v.Timeouts = source.Timeouts
+}
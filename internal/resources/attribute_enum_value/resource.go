@@ -0,0 +1,242 @@
+package attribute_enum_value
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/labd/bluestonepim-go-sdk/pim"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &Resource{}
+	_ resource.ResourceWithConfigure   = &Resource{}
+	_ resource.ResourceWithImportState = &Resource{}
+)
+
+func NewResource() resource.Resource {
+	return &Resource{}
+}
+
+type Resource struct {
+	client             pim.ClientWithResponsesInterface
+	deletionProtection bool
+}
+
+// Metadata returns the data source type name.
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_attribute_enum_value"
+}
+
+// Schema defines the schema for the data source.
+func (r *Resource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Adds a single value to the enum of an existing `single_select` or `multi_select` " +
+			"attribute definition. The value is identified by its number, the other values of the enum are " +
+			"left untouched. Set `exclusive_values` to `false` on the `bluestonepim_attribute_definition` " +
+			"resource managing the attribute definition, otherwise it removes this value again.",
+		Attributes: map[string]schema.Attribute{
+			"attribute_definition_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the attribute definition.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"number": schema.StringAttribute{
+				MarkdownDescription: "The number of the value, unique within the attribute definition.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The value of the enum. Changing the value updates it in place and keeps " +
+					"the product data assigned to it.",
+				Required: true,
+			},
+			"metadata": schema.StringAttribute{
+				MarkdownDescription: "The metadata of the enum.",
+				Optional:            true,
+			},
+			"value_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the value.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deletion_protection": utils.DeletionProtectionAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": utils.TimeoutsBlock(ctx),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, diag := utils.GetProviderData(req.ProviderData)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	r.client = data.PimClient
+	r.deletionProtection = data.DeletionProtection
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan AttributeEnumValue
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, diag := CreateEnumValue(ctx, r.client, &plan)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	result.keepLocalSettings(&plan)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var current AttributeEnumValue
+	diags := req.State.Get(ctx, &current)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, diag := GetEnumValue(ctx, r.client, &current)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	// The value was removed outside of Terraform
+	if result == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	result.keepLocalSettings(&current)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan AttributeEnumValue
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Update(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, diag := UpdateEnumValue(ctx, r.client, &plan)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	result.keepLocalSettings(&plan)
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state AttributeEnumValue
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if d := utils.CheckDeletionProtection(state.DeletionProtection, r.deletionProtection, "enum value "+state.Number.ValueString()+" of attribute definition "+state.AttributeDefinitionID.ValueString()); d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+
+	diag := DeleteEnumValue(ctx, r.client, &state)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+}
+
+// ImportState imports the value using an ID of the form
+// `<attribute_definition_id>:<number>`.
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	definitionID, number, found := strings.Cut(req.ID, ":")
+	if !found || definitionID == "" || number == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <attribute_definition_id>:<number>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("attribute_definition_id"), definitionID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("number"), number)...)
+}
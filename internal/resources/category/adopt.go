@@ -0,0 +1,103 @@
+package category
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/labd/bluestonepim-go-sdk/pim"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// findExistingCategory returns the category adopted by the planned category,
+// matched by number when it is set and by name among the children of the
+// parent otherwise. It returns nil when there is no match.
+func findExistingCategory(ctx context.Context, client pim.ClientWithResponsesInterface, resource *Category) (*pim.CategoryBasicResponse, diag.Diagnostic) {
+	var candidates []pim.CategoryBasicResponse
+	var d diag.Diagnostic
+	var match func(pim.CategoryBasicResponse) bool
+	var key string
+	if !resource.Number.IsNull() && !resource.Number.IsUnknown() {
+		number := resource.Number.ValueString()
+		candidates, d = findCategoriesByNumber(ctx, client, number)
+		match = func(c pim.CategoryBasicResponse) bool { return c.Number != nil && *c.Number == number }
+		key = fmt.Sprintf("number %q", number)
+	} else {
+		candidates, d = listSiblings(ctx, client, resource.ParentId.ValueString())
+		name := resource.Name.ValueString()
+		match = func(c pim.CategoryBasicResponse) bool { return c.Name != nil && *c.Name == name }
+		key = fmt.Sprintf("name %q", name)
+	}
+	if d != nil {
+		return nil, d
+	}
+
+	var found []pim.CategoryBasicResponse
+	for _, candidate := range candidates {
+		if candidate.Id != nil && match(candidate) {
+			found = append(found, candidate)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, diag.NewErrorDiagnostic(
+			"Cannot adopt category",
+			fmt.Sprintf("%d categories with %s exist. Set the number of the category to adopt, or import "+
+				"it instead.", len(found), key),
+		)
+	}
+}
+
+// adoptCategory updates the existing category to the planned values, so it
+// is managed by the resource instead of creating a new one. onCreated is
+// called with its id before it is updated.
+func adoptCategory(
+	ctx context.Context,
+	client pim.ClientWithResponsesInterface,
+	existing *pim.CategoryBasicResponse,
+	planned *Category,
+	onCreated utils.OnCreated,
+) (*Category, diag.Diagnostic) {
+	current := toCategory(existing)
+	if d := onCreated(current.Id.ValueString()); d != nil {
+		return nil, d
+	}
+
+	adopted := *planned
+	adopted.Id = current.Id
+	if adopted.Number.IsUnknown() {
+		adopted.Number = current.Number
+	}
+
+	return UpdateCategory(ctx, client, current, &adopted)
+}
+
+// findCategoriesByNumber returns the categories with the given number.
+func findCategoriesByNumber(ctx context.Context, client pim.ClientWithResponsesInterface, number string) ([]pim.CategoryBasicResponse, diag.Diagnostic) {
+	return utils.CollectPages(ctx, utils.DefaultPageSize, func(ctx context.Context, page utils.Page) ([]pim.CategoryBasicResponse, diag.Diagnostic) {
+		res, err := client.GetFilteredNodesWithResponse(ctx, nil, pim.GetFilteredNodesJSONRequestBody{
+			Filters: &[]pim.CategoryFilter{
+				{
+					Type:   utils.Ref(pim.CategoryFilterTypeNUMBERIN),
+					Values: &[]string{number},
+				},
+			},
+			Page:     utils.Ref(int32(page.Number)),
+			PageSize: utils.Ref(int32(page.Size)),
+		})
+		if err != nil {
+			return nil, diag.NewErrorDiagnostic("Unable to find category", err.Error())
+		}
+		if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+			return nil, d
+		}
+		return utils.Items(res.JSON200.Data), nil
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/labd/bluestonepim-go-sdk/pim"
 	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
@@ -22,14 +23,61 @@ func GetCategoryByID(ctx context.Context, client pim.ClientWithResponsesInterfac
 		return nil, d
 	}
 
-	resource := &Category{
-		Id:          types.StringPointerValue(resp.JSON200.Id),
-		Name:        types.StringPointerValue(resp.JSON200.Name),
-		Number:      types.StringPointerValue(resp.JSON200.Number),
-		ParentId:    types.StringPointerValue(resp.JSON200.ParentId),
-		Description: types.StringPointerValue(resp.JSON200.Description),
+	return toCategory(resp.JSON200), nil
+}
+
+// LoadCategoryByID reads the category together with the other categories
+// read at the same time. Categories that are not returned in bulk are read
+// on their own.
+func LoadCategoryByID(ctx context.Context, client pim.ClientWithResponsesInterface, reads *utils.Batcher[pim.CategoryBasicResponse], id string) (*Category, diag.Diagnostic) {
+	if reads != nil {
+		node, found, d := reads.Load(ctx, id)
+		if d != nil {
+			tflog.Debug(ctx, "Reading categories in bulk failed", map[string]any{"error": d.Detail()})
+		} else if found {
+			return toCategory(node), nil
+		}
+	}
+	return GetCategoryByID(ctx, client, id)
+}
+
+// NewReadBatcher returns a batcher reading categories in bulk.
+func NewReadBatcher(client pim.ClientWithResponsesInterface) *utils.Batcher[pim.CategoryBasicResponse] {
+	return utils.NewBatcher(utils.ReadBatchWait, utils.ReadBatchSize, func(ctx context.Context, ids []string) (map[string]pim.CategoryBasicResponse, diag.Diagnostic) {
+		res, err := client.GetFilteredNodesWithResponse(ctx, nil, pim.GetFilteredNodesJSONRequestBody{
+			Filters: &[]pim.CategoryFilter{
+				{
+					Type:   utils.Ref(pim.CategoryFilterTypeIDIN),
+					Values: &ids,
+				},
+			},
+			PageSize: utils.Ref(int32(len(ids))),
+		})
+		if err != nil {
+			return nil, diag.NewErrorDiagnostic("Unable to read categories", err.Error())
+		}
+		if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+			return nil, d
+		}
+
+		result := make(map[string]pim.CategoryBasicResponse, len(ids))
+		for _, node := range utils.Items(res.JSON200.Data) {
+			if node.Id != nil {
+				result[*node.Id] = node
+			}
+		}
+		return result, nil
+	})
+}
+
+func toCategory(node *pim.CategoryBasicResponse) *Category {
+	return &Category{
+		Id:          types.StringPointerValue(node.Id),
+		Name:        types.StringPointerValue(node.Name),
+		Number:      types.StringPointerValue(node.Number),
+		ParentId:    types.StringPointerValue(node.ParentId),
+		Description: types.StringPointerValue(node.Description),
 	}
-	return resource, nil
 }
 
 func UpdateCategory(ctx context.Context, client pim.ClientWithResponsesInterface, current *Category, planned *Category) (*Category, diag.Diagnostic) {
@@ -64,13 +112,43 @@ func UpdateCategory(ctx context.Context, client pim.ClientWithResponsesInterface
 		}
 	}
 
+	// The state holds the order read from Bluestone PIM, so the category is
+	// only reordered when it differs from the plan or the category moved
+	if !(planned.Position.Equal(current.Position) && planned.SortAfter.Equal(current.SortAfter) &&
+		planned.ParentId.Equal(current.ParentId)) {
+		if d := applyCategoryOrder(ctx, client, current.Id.ValueString(), planned); d != nil {
+			return nil, d
+		}
+	}
+
 	return GetCategoryByID(ctx, client, current.Id.ValueString())
 }
 
-func CreateCategory(ctx context.Context, client pim.ClientWithResponsesInterface, resource *Category) (*Category, diag.Diagnostic) {
+// CreateCategory creates the category and then sets its description and
+// position, which cannot be set on create. onCreated is called with the id
+// before these follow-up calls. When AdoptExisting is set and a matching
+// category exists, it is adopted instead.
+func CreateCategory(
+	ctx context.Context,
+	client pim.ClientWithResponsesInterface,
+	resource *Category,
+	onCreated utils.OnCreated,
+) (*Category, diag.Diagnostic) {
+	if resource.AdoptExisting.ValueBool() {
+		existing, d := findExistingCategory(ctx, client, resource)
+		if d != nil {
+			return nil, d
+		}
+		if existing != nil {
+			return adoptCategory(ctx, client, existing, resource, onCreated)
+		}
+	}
+
+	// Bluestone PIM only supports name validation for categories, so unlike
+	// attribute definitions, duplicate validation is not configurable
 	res, err := client.CreateCategoryWithResponse(ctx,
 		&pim.CreateCategoryParams{
-			Validation: "NAME",
+			Validation: pim.CreateCategoryParamsValidationNAME,
 		},
 		pim.CreateCategoryJSONRequestBody{
 			Name:     resource.Name.ValueString(),
@@ -89,6 +167,14 @@ func CreateCategory(ctx context.Context, client pim.ClientWithResponsesInterface
 	}
 
 	resourceId := res.HTTPResponse.Header.Get("Resource-Id")
+	if d := onCreated(resourceId); d != nil {
+		return nil, d
+	}
+
+	// New categories are not always found right away
+	if d := waitForCategory(ctx, client, resourceId); d != nil {
+		return nil, d
+	}
 
 	//Workaround because we cannot set description on create
 	resU, err := client.UpdateCatalogNodeWithResponse(ctx, resourceId, nil, pim.UpdateCatalogNodeJSONRequestBody{
@@ -104,18 +190,23 @@ func CreateCategory(ctx context.Context, client pim.ClientWithResponsesInterface
 		return nil, d
 	}
 
-	return GetCategoryByID(ctx, client, resourceId)
-}
-
-func DeleteCategory(ctx context.Context, client pim.ClientWithResponsesInterface, resource *Category) diag.Diagnostic {
-	response, err := client.DeleteCategoryNodeWithResponse(ctx, resource.Id.ValueString())
-	if err != nil {
-		return diag.NewErrorDiagnostic("Unable to delete category", err.Error())
+	if d := applyCategoryOrder(ctx, client, resourceId, resource); d != nil {
+		return nil, d
 	}
 
-	if d := utils.AssertStatusCode(response, http.StatusNoContent); d != nil {
-		return d
-	}
+	return GetCategoryByID(ctx, client, resourceId)
+}
 
-	return nil
+// waitForCategory waits until the created category is found.
+func waitForCategory(ctx context.Context, client pim.ClientWithResponsesInterface, id string) diag.Diagnostic {
+	return utils.WaitFor(ctx, "category "+id+" to be created", func(ctx context.Context) (bool, diag.Diagnostic) {
+		res, err := client.GetNodeWithResponse(ctx, id, nil)
+		if err != nil {
+			return false, diag.NewErrorDiagnostic("Unable to read data", err.Error())
+		}
+		if utils.IsNotFound(res) {
+			return false, nil
+		}
+		return true, utils.AssertStatusCode(res, http.StatusOK)
+	})
 }
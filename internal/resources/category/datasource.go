@@ -42,6 +42,10 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 				MarkdownDescription: "Name",
 				Computed:            true,
 			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the Category.",
+				Computed:            true,
+			},
 			"parent_id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the parent Category.",
 				Computed:            true,
@@ -65,7 +69,7 @@ func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequ
 }
 
 func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data Category
+	var data CategoryData
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -79,6 +83,14 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 		return
 	}
 
+	result := CategoryData{
+		Id:          resource.Id,
+		Name:        resource.Name,
+		Number:      resource.Number,
+		Description: resource.Description,
+		ParentId:    resource.ParentId,
+	}
+
 	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &resource)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &result)...)
 }
@@ -0,0 +1,261 @@
+package category
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/labd/bluestonepim-go-sdk/pim"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// The strategies to delete a category that still has subcategories or
+// products.
+const (
+	OnDeleteFail     = "fail"
+	OnDeleteReassign = "reassign"
+	OnDeleteCascade  = "cascade"
+)
+
+// DeleteCategory deletes the category. Subcategories and products of the
+// category are handled according to its on_delete strategy: they block the
+// deletion, are moved to the reassign_to category, or are removed with it.
+func DeleteCategory(ctx context.Context, client pim.ClientWithResponsesInterface, resource *Category) diag.Diagnostic {
+	id := resource.Id.ValueString()
+
+	switch resource.OnDelete.ValueString() {
+	case OnDeleteReassign:
+		if d := reassignCategoryContent(ctx, client, id, resource.ReassignTo.ValueString()); d != nil {
+			return d
+		}
+	case OnDeleteCascade:
+		return deleteCategoryTree(ctx, client, id)
+	default:
+		if d := assertCategoryEmpty(ctx, client, id); d != nil {
+			return d
+		}
+	}
+
+	return deleteCategoryNode(ctx, client, id)
+}
+
+// assertCategoryEmpty returns an error listing the subcategories and the
+// number of products of the category when it is not empty.
+func assertCategoryEmpty(ctx context.Context, client pim.ClientWithResponsesInterface, id string) diag.Diagnostic {
+	children, d := ListCategoryChildren(ctx, client, id)
+	if d != nil {
+		return d
+	}
+	products, d := ListCategoryProducts(ctx, client, id)
+	if d != nil {
+		return d
+	}
+	if len(children) == 0 && len(products) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(children))
+	for _, child := range children {
+		if child.Name != nil {
+			names = append(names, fmt.Sprintf("%q", *child.Name))
+		} else if child.Id != nil {
+			names = append(names, *child.Id)
+		}
+	}
+
+	detail := fmt.Sprintf("Category %s has %d subcategories and %d products.", id, len(children), len(products))
+	if len(names) > 0 {
+		detail += fmt.Sprintf(" Subcategories: %s.", strings.Join(names, ", "))
+	}
+	detail += fmt.Sprintf(" Remove them first, or set on_delete to %q or %q.", OnDeleteReassign, OnDeleteCascade)
+
+	return diag.NewErrorDiagnostic("Category not empty", detail)
+}
+
+// reassignCategoryContent moves the subcategories and products of the
+// category to the target category. The target must not be the category or one
+// of its subcategories, as those are deleted with it.
+func reassignCategoryContent(ctx context.Context, client pim.ClientWithResponsesInterface, id, target string) diag.Diagnostic {
+	inSubtree, d := isInSubtree(ctx, client, id, target)
+	if d != nil {
+		return d
+	}
+	if inSubtree {
+		return diag.NewErrorDiagnostic(
+			"Invalid reassign_to",
+			fmt.Sprintf("Category %s in reassign_to is category %s or one of its subcategories, which are "+
+				"deleted with it. Reassign the content to a category outside of it.", target, id),
+		)
+	}
+
+	children, d := ListCategoryChildren(ctx, client, id)
+	if d != nil {
+		return d
+	}
+	for _, child := range children {
+		if child.Id == nil {
+			continue
+		}
+		res, err := client.MoveCatalogNodeWithResponse(ctx, *child.Id, pim.MoveCatalogNodeJSONRequestBody{
+			ParentId: utils.Ref(target),
+		})
+		if err != nil {
+			return diag.NewErrorDiagnostic("Unable to move subcategory", err.Error())
+		}
+		if d := utils.AssertStatusCode(res, http.StatusNoContent); d != nil {
+			return d
+		}
+	}
+
+	products, d := ListCategoryProducts(ctx, client, id)
+	if d != nil {
+		return d
+	}
+	for _, product := range products {
+		if product.ProductId == nil {
+			continue
+		}
+		res, err := client.AddCategoryProductsWithResponse(ctx, target, pim.AddCategoryProductsJSONRequestBody{
+			ProductId: product.ProductId,
+		})
+		if err != nil {
+			return diag.NewErrorDiagnostic("Unable to assign product to category", err.Error())
+		}
+		if d := utils.AssertStatusCode(res, http.StatusNoContent); d != nil {
+			return d
+		}
+
+		if d := removeCategoryProduct(ctx, client, id, *product.ProductId); d != nil {
+			return d
+		}
+	}
+
+	return nil
+}
+
+// deleteCategoryTree deletes the category and all of its subcategories. The
+// products are removed from the categories, the products themselves are
+// kept. The deletion protection of subcategories managed by other resources
+// is not known here, so it is not checked.
+func deleteCategoryTree(ctx context.Context, client pim.ClientWithResponsesInterface, id string) diag.Diagnostic {
+	children, d := ListCategoryChildren(ctx, client, id)
+	if d != nil {
+		return d
+	}
+	for _, child := range children {
+		if child.Id == nil {
+			continue
+		}
+		if d := deleteCategoryTree(ctx, client, *child.Id); d != nil {
+			return d
+		}
+	}
+
+	products, d := ListCategoryProducts(ctx, client, id)
+	if d != nil {
+		return d
+	}
+	for _, product := range products {
+		if product.ProductId == nil {
+			continue
+		}
+		if d := removeCategoryProduct(ctx, client, id, *product.ProductId); d != nil {
+			return d
+		}
+	}
+
+	return deleteCategoryNode(ctx, client, id)
+}
+
+// isInSubtree returns whether target is the category or one of its
+// subcategories.
+func isInSubtree(ctx context.Context, client pim.ClientWithResponsesInterface, id, target string) (bool, diag.Diagnostic) {
+	if id == target {
+		return true, nil
+	}
+	children, d := ListCategoryChildren(ctx, client, id)
+	if d != nil {
+		return false, d
+	}
+	for _, child := range children {
+		if child.Id == nil {
+			continue
+		}
+		found, d := isInSubtree(ctx, client, *child.Id, target)
+		if d != nil || found {
+			return found, d
+		}
+	}
+	return false, nil
+}
+
+func deleteCategoryNode(ctx context.Context, client pim.ClientWithResponsesInterface, id string) diag.Diagnostic {
+	response, err := client.DeleteCategoryNodeWithResponse(ctx, id)
+	if err != nil {
+		return diag.NewErrorDiagnostic("Unable to delete category", err.Error())
+	}
+
+	return utils.AssertStatusCode(response, http.StatusNoContent)
+}
+
+func removeCategoryProduct(ctx context.Context, client pim.ClientWithResponsesInterface, id, productID string) diag.Diagnostic {
+	res, err := client.DeleteCategoryProductsWithResponse(ctx, id, productID)
+	if err != nil {
+		return diag.NewErrorDiagnostic("Unable to remove product from category", err.Error())
+	}
+
+	return utils.AssertStatusCode(res, http.StatusNoContent)
+}
+
+// ListCategoryChildren returns the direct subcategories of a category.
+func ListCategoryChildren(ctx context.Context, client pim.ClientWithResponsesInterface, id string) ([]pim.CategoryBasicResponse, diag.Diagnostic) {
+	return utils.CollectPages(ctx, utils.DefaultPageSize, func(ctx context.Context, page utils.Page) ([]pim.CategoryBasicResponse, diag.Diagnostic) {
+		res, err := client.GetCatalogNodeChildrenWithResponse(ctx, id, &pim.GetCatalogNodeChildrenParams{
+			Page:     utils.Ref(int32(page.Number)),
+			PageSize: utils.Ref(int32(page.Size)),
+		})
+		if err != nil {
+			return nil, diag.NewErrorDiagnostic("Unable to list subcategories", err.Error())
+		}
+		if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+			return nil, d
+		}
+		return utils.Items(res.JSON200.Data), nil
+	})
+}
+
+// ListCategoryProducts returns the products assigned to a category.
+func ListCategoryProducts(ctx context.Context, client pim.ClientWithResponsesInterface, id string) ([]pim.CategoryProductResponse, diag.Diagnostic) {
+	return utils.CollectPages(ctx, utils.DefaultPageSize, func(ctx context.Context, page utils.Page) ([]pim.CategoryProductResponse, diag.Diagnostic) {
+		res, err := client.GetCategoryProductsWithResponse(ctx, id, &pim.GetCategoryProductsParams{
+			Page:     utils.Ref(int32(page.Number)),
+			PageSize: utils.Ref(int32(page.Size)),
+		})
+		if err != nil {
+			return nil, diag.NewErrorDiagnostic("Unable to list category products", err.Error())
+		}
+		if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+			return nil, d
+		}
+		return utils.Items(res.JSON200.Data), nil
+	})
+}
+
+// ListCategoryAttributes returns the attributes of a category, both the ones
+// assigned on the category and the ones inherited from its parents.
+func ListCategoryAttributes(ctx context.Context, client pim.ClientWithResponsesInterface, id string) ([]pim.CategoryAttributeMetadataResponse, diag.Diagnostic) {
+	return utils.CollectPages(ctx, utils.DefaultPageSize, func(ctx context.Context, page utils.Page) ([]pim.CategoryAttributeMetadataResponse, diag.Diagnostic) {
+		res, err := client.ListAttributesAttachedToGivenNodeWithResponse(ctx, id,
+			&pim.ListAttributesAttachedToGivenNodeParams{}, utils.PageQuery(page))
+		if err != nil {
+			return nil, diag.NewErrorDiagnostic("Unable to list category attributes", err.Error())
+		}
+		if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+			return nil, d
+		}
+		return utils.Items(res.JSON200.Data), nil
+	})
+}
@@ -0,0 +1,215 @@
+package category
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/bluestonepim-go-sdk/pim"
+)
+
+// fakeCatalog serves the category endpoints used to delete categories from
+// an in-memory tree.
+type fakeCatalog struct {
+	mu       sync.Mutex
+	parents  map[string]string
+	products map[string][]string
+}
+
+func newFakeCatalog(t *testing.T, parents map[string]string, products map[string][]string) (*fakeCatalog, pim.ClientWithResponsesInterface) {
+	t.Helper()
+	catalog := &fakeCatalog{parents: parents, products: products}
+	server := httptest.NewServer(catalog)
+	t.Cleanup(server.Close)
+
+	client, err := pim.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return catalog, client
+}
+
+func (c *fakeCatalog) children(id string) []string {
+	var children []string
+	for child, parent := range c.parents {
+		if parent == id {
+			children = append(children, child)
+		}
+	}
+	slices.Sort(children)
+	return children
+}
+
+func (c *fakeCatalog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	segments := strings.Split(strings.TrimPrefix(r.URL.Path, "/catalogs/nodes/"), "/")
+	id := segments[0]
+	if _, ok := c.parents[id]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var body struct {
+		ParentId  string `json:"parentId"`
+		ProductId string `json:"productId"`
+	}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	switch {
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[1] == "children":
+		data := []pim.CategoryBasicResponse{}
+		for _, child := range c.children(id) {
+			data = append(data, pim.CategoryBasicResponse{Id: &child, Name: &child})
+		}
+		writeJSON(w, pim.ListableCategoryBasicResponse{Data: &data})
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[1] == "products":
+		data := []pim.CategoryProductResponse{}
+		for _, product := range c.products[id] {
+			data = append(data, pim.CategoryProductResponse{ProductId: &product})
+		}
+		writeJSON(w, pim.ListableCategoryProductResponse{Data: &data})
+	case r.Method == http.MethodPut && len(segments) == 2 && segments[1] == "move":
+		c.parents[id] = body.ParentId
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost && len(segments) == 2 && segments[1] == "products":
+		c.products[id] = append(c.products[id], body.ProductId)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete && len(segments) == 3 && segments[1] == "products":
+		c.products[id] = slices.DeleteFunc(c.products[id], func(p string) bool { return p == segments[2] })
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete && len(segments) == 1:
+		// Like Bluestone PIM, only empty categories can be deleted
+		if len(c.children(id)) > 0 || len(c.products[id]) > 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		delete(c.parents, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func testCategory(id, onDelete, reassignTo string) *Category {
+	category := &Category{
+		Id:         types.StringValue(id),
+		OnDelete:   types.StringValue(onDelete),
+		ReassignTo: types.StringNull(),
+	}
+	if reassignTo != "" {
+		category.ReassignTo = types.StringValue(reassignTo)
+	}
+	return category
+}
+
+func TestDeleteCategoryFailsWhenNotEmpty(t *testing.T) {
+	catalog, client := newFakeCatalog(t,
+		map[string]string{"root": "", "parent": "root", "child": "parent"},
+		map[string][]string{"parent": {"product"}},
+	)
+
+	d := DeleteCategory(context.Background(), client, testCategory("parent", OnDeleteFail, ""))
+	if d == nil || d.Summary() != "Category not empty" {
+		t.Fatalf("expected a category not empty error, got %v", d)
+	}
+	if !strings.Contains(d.Detail(), "1 subcategories and 1 products") || !strings.Contains(d.Detail(), `"child"`) {
+		t.Errorf("expected the blocking content in the error, got %q", d.Detail())
+	}
+	if _, ok := catalog.parents["parent"]; !ok {
+		t.Error("expected the category to be kept")
+	}
+}
+
+func TestDeleteCategoryReassignsContent(t *testing.T) {
+	catalog, client := newFakeCatalog(t,
+		map[string]string{"root": "", "parent": "root", "child": "parent", "target": "root"},
+		map[string][]string{"parent": {"product"}},
+	)
+
+	if d := DeleteCategory(context.Background(), client, testCategory("parent", OnDeleteReassign, "target")); d != nil {
+		t.Fatalf("unexpected error %v", d)
+	}
+	if _, ok := catalog.parents["parent"]; ok {
+		t.Error("expected the category to be deleted")
+	}
+	if catalog.parents["child"] != "target" {
+		t.Errorf("expected the subcategory to be moved to the target, got parent %q", catalog.parents["child"])
+	}
+	if !slices.Equal(catalog.products["target"], []string{"product"}) {
+		t.Errorf("expected the product to be assigned to the target, got %v", catalog.products["target"])
+	}
+}
+
+func TestDeleteCategoryCascades(t *testing.T) {
+	catalog, client := newFakeCatalog(t,
+		map[string]string{"root": "", "parent": "root", "child": "parent", "grandchild": "child"},
+		map[string][]string{"parent": {"a"}, "grandchild": {"b"}},
+	)
+
+	if d := DeleteCategory(context.Background(), client, testCategory("parent", OnDeleteCascade, "")); d != nil {
+		t.Fatalf("unexpected error %v", d)
+	}
+	for _, id := range []string{"parent", "child", "grandchild"} {
+		if _, ok := catalog.parents[id]; ok {
+			t.Errorf("expected category %s to be deleted", id)
+		}
+	}
+	if _, ok := catalog.parents["root"]; !ok {
+		t.Error("expected the parent of the deleted category to be kept")
+	}
+}
+
+func TestDeleteCategoryRejectsReassignToSubcategory(t *testing.T) {
+	catalog, client := newFakeCatalog(t,
+		map[string]string{"root": "", "parent": "root", "child": "parent", "grandchild": "child"},
+		map[string][]string{"parent": {"product"}},
+	)
+
+	d := DeleteCategory(context.Background(), client, testCategory("parent", OnDeleteReassign, "grandchild"))
+	if d == nil || d.Summary() != "Invalid reassign_to" {
+		t.Fatalf("expected an invalid reassign_to error, got %v", d)
+	}
+	if catalog.parents["child"] != "parent" || !slices.Equal(catalog.products["parent"], []string{"product"}) {
+		t.Error("expected nothing to be moved")
+	}
+}
+
+func TestDeleteCategoryCascadeSkipsProductsWithoutID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/children"):
+			writeJSON(w, pim.ListableCategoryBasicResponse{Data: &[]pim.CategoryBasicResponse{{}}})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/products"):
+			writeJSON(w, pim.ListableCategoryProductResponse{Data: &[]pim.CategoryProductResponse{{}}})
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusNotImplemented)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := pim.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := DeleteCategory(context.Background(), client, testCategory("parent", OnDeleteCascade, ""))
+	if d == nil {
+		t.Fatal("expected the failing delete to be reported")
+	}
+}
@@ -1,12 +1,67 @@
 package category
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
-// Category describes the data source data model.
+// Category describes the resource data model.
 type Category struct {
 	Id          types.String `tfsdk:"id"`
 	Name        types.String `tfsdk:"name"`
 	Number      types.String `tfsdk:"number"`
 	Description types.String `tfsdk:"description"`
 	ParentId    types.String `tfsdk:"parent_id"`
+	Position    types.Int64  `tfsdk:"position"`
+	SortAfter   types.String `tfsdk:"sort_after"`
+
+	AdoptExisting      types.Bool     `tfsdk:"adopt_existing"`
+	OnDelete           types.String   `tfsdk:"on_delete"`
+	ReassignTo         types.String   `tfsdk:"reassign_to"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// keepLocalSettings copies the settings that are not stored in Bluestone PIM
+// from source. The ordering settings are copied as well, as the order is only
+// read back when it is managed.
+func (c *Category) keepLocalSettings(source *Category) {
+	c.Position = source.Position
+	c.SortAfter = source.SortAfter
+	c.AdoptExisting = source.AdoptExisting
+	c.OnDelete = source.OnDelete
+	c.ReassignTo = source.ReassignTo
+	c.DeletionProtection = source.DeletionProtection
+	c.Timeouts = source.Timeouts
+	if c.AdoptExisting.IsNull() {
+		c.AdoptExisting = types.BoolValue(false)
+	}
+	if c.OnDelete.IsNull() {
+		c.OnDelete = types.StringValue(OnDeleteFail)
+	}
+}
+
+// CategoryData describes the data source data model.
+type CategoryData struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Number      types.String `tfsdk:"number"`
+	Description types.String `tfsdk:"description"`
+	ParentId    types.String `tfsdk:"parent_id"`
+}
+
+// CategoryPathData describes the category path data source data model.
+type CategoryPathData struct {
+	Path      types.String          `tfsdk:"path"`
+	Separator types.String          `tfsdk:"separator"`
+	RootID    types.String          `tfsdk:"root_id"`
+	ID        types.String          `tfsdk:"id"`
+	Segments  []CategoryPathSegment `tfsdk:"segments"`
+}
+
+// CategoryPathSegment is a category on a category path.
+type CategoryPathSegment struct {
+	ID     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Number types.String `tfsdk:"number"`
 }
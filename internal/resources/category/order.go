@@ -0,0 +1,142 @@
+package category
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/bluestonepim-go-sdk/pim"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// applyCategoryOrder moves the category to the position among its siblings
+// configured with position or sort_after. Nothing is changed when neither is
+// set or the category is already in place.
+func applyCategoryOrder(ctx context.Context, client pim.ClientWithResponsesInterface, id string, resource *Category) diag.Diagnostic {
+	if resource.Position.IsNull() && resource.SortAfter.IsNull() {
+		return nil
+	}
+
+	siblings, d := listSiblingIDs(ctx, client, resource.ParentId.ValueString())
+	if d != nil {
+		return d
+	}
+	current := slices.Index(siblings, id)
+	if current < 0 {
+		return diag.NewErrorDiagnostic(
+			"Unable to order category",
+			fmt.Sprintf("Category %s was not found among the children of its parent.", id),
+		)
+	}
+
+	var target int
+	if !resource.Position.IsNull() {
+		// A position beyond the last sibling places the category last
+		target = min(int(resource.Position.ValueInt64()), len(siblings)-1)
+	} else {
+		after := slices.Index(siblings, resource.SortAfter.ValueString())
+		if after < 0 {
+			return diag.NewErrorDiagnostic(
+				"Unable to order category",
+				fmt.Sprintf("Category %s in sort_after is not a sibling of category %s.", resource.SortAfter.ValueString(), id),
+			)
+		}
+		// Moving the category down shifts the categories after it up by one
+		target = after + 1
+		if current < after {
+			target = after
+		}
+	}
+	if target == current {
+		return nil
+	}
+
+	res, err := client.UpdateCatalogNodeOrderWithResponse(ctx, id, &pim.UpdateCatalogNodeOrderParams{
+		TargetPosition: int32(target),
+	})
+	if err != nil {
+		return diag.NewErrorDiagnostic("Unable to order category", err.Error())
+	}
+
+	return utils.AssertStatusCode(res, http.StatusNoContent)
+}
+
+// readCategoryOrder refreshes the position and sort_after of the category
+// when they are managed, so changes made in Bluestone PIM show up as drift.
+func readCategoryOrder(ctx context.Context, client pim.ClientWithResponsesInterface, resource *Category) diag.Diagnostic {
+	if resource.Position.IsNull() && resource.SortAfter.IsNull() {
+		return nil
+	}
+
+	siblings, d := listSiblingIDs(ctx, client, resource.ParentId.ValueString())
+	if d != nil {
+		return d
+	}
+	current := slices.Index(siblings, resource.Id.ValueString())
+	if current < 0 {
+		return nil
+	}
+
+	if !resource.Position.IsNull() {
+		last := len(siblings) - 1
+		if current != last || resource.Position.ValueInt64() < int64(last) {
+			resource.Position = types.Int64Value(int64(current))
+		}
+	}
+	if !resource.SortAfter.IsNull() {
+		if current == 0 {
+			// No sibling precedes the first category
+			resource.SortAfter = types.StringNull()
+		} else {
+			resource.SortAfter = types.StringValue(siblings[current-1])
+		}
+	}
+	return nil
+}
+
+// listSiblings returns the children of the parent in their order.
+// Categories without a parent are catalogs.
+func listSiblings(ctx context.Context, client pim.ClientWithResponsesInterface, parentID string) ([]pim.CategoryBasicResponse, diag.Diagnostic) {
+	if parentID == "" {
+		return listCatalogs(ctx, client)
+	}
+	return ListCategoryChildren(ctx, client, parentID)
+}
+
+// listSiblingIDs returns the IDs of the children of the parent in their
+// order.
+func listSiblingIDs(ctx context.Context, client pim.ClientWithResponsesInterface, parentID string) ([]string, diag.Diagnostic) {
+	siblings, d := listSiblings(ctx, client, parentID)
+	if d != nil {
+		return nil, d
+	}
+
+	ids := make([]string, 0, len(siblings))
+	for _, sibling := range siblings {
+		if sibling.Id != nil {
+			ids = append(ids, *sibling.Id)
+		}
+	}
+	return ids, nil
+}
+
+// listCatalogs returns the categories without a parent.
+func listCatalogs(ctx context.Context, client pim.ClientWithResponsesInterface) ([]pim.CategoryBasicResponse, diag.Diagnostic) {
+	return utils.CollectPages(ctx, utils.DefaultPageSize, func(ctx context.Context, page utils.Page) ([]pim.CategoryBasicResponse, diag.Diagnostic) {
+		res, err := client.ListCatalogsWithResponse(ctx, &pim.ListCatalogsParams{
+			Page:     utils.Ref(int32(page.Number)),
+			PageSize: utils.Ref(int32(page.Size)),
+		})
+		if err != nil {
+			return nil, diag.NewErrorDiagnostic("Unable to list catalogs", err.Error())
+		}
+		if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+			return nil, d
+		}
+		return utils.Items(res.JSON200.Data), nil
+	})
+}
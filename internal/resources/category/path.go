@@ -0,0 +1,101 @@
+package category
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/bluestonepim-go-sdk/pim"
+)
+
+// ResolveCategoryPath walks the category tree along the names in path,
+// separated by separator, and returns every category on the way. The walk
+// starts at the catalogs, or at the children of rootID when it is set.
+func ResolveCategoryPath(ctx context.Context, client pim.ClientWithResponsesInterface, path, separator, rootID string) ([]CategoryPathSegment, diag.Diagnostic) {
+	names := strings.Split(path, separator)
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+		if names[i] == "" {
+			return nil, diag.NewErrorDiagnostic(
+				"Invalid category path",
+				fmt.Sprintf("Segment %d of path %q is empty.", i+1, path),
+			)
+		}
+	}
+
+	parentID, parentName := rootID, "the catalogs"
+	if rootID != "" {
+		root, d := GetCategoryByID(ctx, client, rootID)
+		if d != nil {
+			return nil, d
+		}
+		parentName = fmt.Sprintf("category %q", root.Name.ValueString())
+	}
+
+	segments := make([]CategoryPathSegment, 0, len(names))
+	for _, name := range names {
+		var candidates []pim.CategoryBasicResponse
+		var d diag.Diagnostic
+		if parentID == "" {
+			candidates, d = listCatalogs(ctx, client)
+		} else {
+			candidates, d = ListCategoryChildren(ctx, client, parentID)
+		}
+		if d != nil {
+			return nil, d
+		}
+
+		var matches []pim.CategoryBasicResponse
+		for _, candidate := range candidates {
+			if candidate.Name != nil && *candidate.Name == name && candidate.Id != nil {
+				matches = append(matches, candidate)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			return nil, diag.NewErrorDiagnostic(
+				"Category not found",
+				fmt.Sprintf("No category named %q exists in %s. Available: %s.", name, parentName, categoryNames(candidates)),
+			)
+		case 1:
+		default:
+			ids := make([]string, 0, len(matches))
+			for _, match := range matches {
+				ids = append(ids, *match.Id)
+			}
+			return nil, diag.NewErrorDiagnostic(
+				"Ambiguous category path",
+				fmt.Sprintf("%d categories named %q exist in %s: %s.", len(matches), name, parentName, strings.Join(ids, ", ")),
+			)
+		}
+
+		match := matches[0]
+		segments = append(segments, CategoryPathSegment{
+			ID:     types.StringPointerValue(match.Id),
+			Name:   types.StringPointerValue(match.Name),
+			Number: types.StringPointerValue(match.Number),
+		})
+		parentID, parentName = *match.Id, fmt.Sprintf("category %q", name)
+	}
+
+	return segments, nil
+}
+
+// categoryNames returns the quoted names of the categories for use in
+// diagnostics.
+func categoryNames(categories []pim.CategoryBasicResponse) string {
+	if len(categories) == 0 {
+		return "none"
+	}
+
+	names := make([]string, 0, len(categories))
+	for _, category := range categories {
+		if category.Name != nil {
+			names = append(names, fmt.Sprintf("%q", *category.Name))
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,126 @@
+package category
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/labd/bluestonepim-go-sdk/pim"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PathDataSource{}
+
+// defaultPathSeparator separates the category names of a path when no
+// separator is configured.
+const defaultPathSeparator = ">"
+
+func NewPathDataSource() datasource.DataSource {
+	return &PathDataSource{}
+}
+
+// PathDataSource defines the category path data source implementation.
+type PathDataSource struct {
+	client pim.ClientWithResponsesInterface
+}
+
+func (d *PathDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_category_path"
+}
+
+func (d *PathDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a category by its breadcrumb path, such as `Apparel > Shoes > Sneakers`. " +
+			"Each segment is matched on the exact category name, leading and trailing whitespace is ignored.",
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The names of the categories from the top down, separated by `separator`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"separator": schema.StringAttribute{
+				MarkdownDescription: "The separator between the names in `path`. Defaults to `>`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"root_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the category the path starts in. By default the path starts " +
+					"with the name of a catalog.",
+				Optional: true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the last category of the path.",
+				Computed:            true,
+			},
+			"segments": schema.ListNestedAttribute{
+				MarkdownDescription: "The categories on the path, from the top down.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the category.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the category.",
+							Computed:            true,
+						},
+						"number": schema.StringAttribute{
+							MarkdownDescription: "The number of the category.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PathDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, diag := utils.GetProviderData(req.ProviderData)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	d.client = data.PimClient
+}
+
+func (d *PathDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CategoryPathData
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	separator := defaultPathSeparator
+	if !data.Separator.IsNull() {
+		separator = data.Separator.ValueString()
+	}
+
+	segments, diag := ResolveCategoryPath(ctx, d.client, data.Path.ValueString(), separator, data.RootID.ValueString())
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	data.Segments = segments
+	data.ID = segments[len(segments)-1].ID
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -2,6 +2,8 @@ package category
 
 import (
 	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/labd/bluestonepim-go-sdk/pim"
@@ -9,17 +11,22 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &Resource{}
-	_ resource.ResourceWithConfigure   = &Resource{}
-	_ resource.ResourceWithImportState = &Resource{}
+	_ resource.Resource                   = &Resource{}
+	_ resource.ResourceWithConfigure      = &Resource{}
+	_ resource.ResourceWithImportState    = &Resource{}
+	_ resource.ResourceWithValidateConfig = &Resource{}
+	_ resource.ResourceWithModifyPlan     = &Resource{}
 )
 
 func NewResource() resource.Resource {
@@ -27,7 +34,9 @@ func NewResource() resource.Resource {
 }
 
 type Resource struct {
-	client pim.ClientWithResponsesInterface
+	client             pim.ClientWithResponsesInterface
+	reads              *utils.Batcher[pim.CategoryBasicResponse]
+	deletionProtection bool
 }
 
 // Metadata returns the data source type name.
@@ -36,7 +45,7 @@ func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, res
 }
 
 // Schema defines the schema for the data source.
-func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *Resource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "",
 		Attributes: map[string]schema.Attribute{
@@ -56,8 +65,10 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The name of the Category.",
-				Required:            true,
+				MarkdownDescription: "The name of the Category. Bluestone PIM always checks the name for " +
+					"duplicates when the category is created, `duplicate_validation` only applies to attribute " +
+					"definitions.",
+				Required: true,
 			},
 			"description": schema.StringAttribute{
 				MarkdownDescription: "The description of the Category.",
@@ -67,6 +78,56 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 				MarkdownDescription: "The ID of the parent Category.",
 				Optional:            true,
 			},
+			"position": schema.Int64Attribute{
+				MarkdownDescription: "The zero-based position of the category among its siblings. A position " +
+					"beyond the last sibling places the category last. Conflicts with `sort_after`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+					int64validator.ConflictsWith(path.MatchRoot("sort_after")),
+				},
+			},
+			"sort_after": schema.StringAttribute{
+				MarkdownDescription: "The ID of the sibling category this category is placed directly after. " +
+					"A category that is first among its siblings is read without `sort_after`, use `position` " +
+					"`0` to place it first. Conflicts with `position`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to adopt an existing category on create instead of failing when " +
+					"the name is already used. It is matched by `number` when set, and by `name` among the " +
+					"children of `parent_id` otherwise. The adopted category is updated to the configured values, " +
+					"including moving it to `parent_id`, and managed by this resource.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"on_delete": schema.StringAttribute{
+				MarkdownDescription: "What to do with the subcategories and products of the category when it is " +
+					"deleted. `fail` stops the deletion and reports them, `reassign` moves them to the " +
+					"`reassign_to` category first, and `cascade` deletes all subcategories and removes the " +
+					"products from them. Products themselves are never deleted. `cascade` overrides the " +
+					"`deletion_protection` of subcategories managed by other `bluestonepim_category` resources, " +
+					"as the provider cannot see their settings. Defaults to `fail`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(OnDeleteFail),
+				Validators: []validator.String{
+					stringvalidator.OneOf(OnDeleteFail, OnDeleteReassign, OnDeleteCascade),
+				},
+			},
+			"reassign_to": schema.StringAttribute{
+				MarkdownDescription: "The ID of the category that receives the subcategories and products when " +
+					"`on_delete` is `reassign`. It must not be the category itself or one of its subcategories.",
+				Optional: true,
+			},
+			"deletion_protection": utils.DeletionProtectionAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": utils.TimeoutsBlock(ctx),
 		},
 	}
 }
@@ -84,6 +145,8 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, r
 	}
 
 	r.client = data.PimClient
+	r.reads = data.CategoryReads
+	r.deletionProtection = data.DeletionProtection
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -95,12 +158,22 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
-	result, diag := CreateCategory(ctx, r.client, &plan)
+	timeout, diags := plan.Timeouts.Create(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, diag := CreateCategory(ctx, r.client, &plan, utils.SaveCreatedID(ctx, &resp.State, plan, path.Root("id")))
 	if diag != nil {
 		resp.Diagnostics.Append(diag)
 		return
 	}
 
+	result.keepLocalSettings(&plan)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, result)
 	resp.Diagnostics.Append(diags...)
@@ -118,12 +191,18 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
-	result, diag := GetCategoryByID(ctx, r.client, current.Id.ValueString())
+	result, diag := LoadCategoryByID(ctx, r.client, r.reads, current.Id.ValueString())
 	if diag != nil {
 		resp.Diagnostics.Append(diag)
 		return
 	}
 
+	result.keepLocalSettings(&current)
+	if diag := readCategoryOrder(ctx, r.client, result); diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, result)
 	resp.Diagnostics.Append(diags...)
@@ -142,6 +221,14 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	timeout, diags := plan.Timeouts.Update(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	// Retrieve values from state
 	var state Category
 	diags = req.State.Get(ctx, &state)
@@ -156,6 +243,8 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	result.keepLocalSettings(&plan)
+
 	diags = resp.State.Set(ctx, result)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -173,6 +262,19 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
+	timeout, diags := state.Timeouts.Delete(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if d := utils.CheckDeletionProtection(state.DeletionProtection, r.deletionProtection, "category "+state.Id.ValueString()); d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+
 	diag := DeleteCategory(ctx, r.client, &state)
 	if diag != nil {
 		resp.Diagnostics.Append(diag)
@@ -185,3 +287,53 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// ValidateConfig checks that reassign_to is set when, and only when, the
+// content of the category is reassigned on delete.
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var onDelete, reassignTo types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("on_delete"), &onDelete)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("reassign_to"), &reassignTo)...)
+	if resp.Diagnostics.HasError() || onDelete.IsUnknown() {
+		return
+	}
+
+	if onDelete.ValueString() == OnDeleteReassign && reassignTo.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("reassign_to"),
+			"Missing reassign_to",
+			fmt.Sprintf("reassign_to is required when on_delete is %q.", OnDeleteReassign),
+		)
+	}
+	if onDelete.ValueString() != OnDeleteReassign && !reassignTo.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("reassign_to"),
+			"Unexpected reassign_to",
+			fmt.Sprintf("reassign_to only applies when on_delete is %q.", OnDeleteReassign),
+		)
+	}
+}
+
+// ModifyPlan checks that reassign_to is not the category itself. The id is
+// only known once the category exists, so this cannot be checked in
+// ValidateConfig. Subcategories are checked when the category is deleted.
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var id, reassignTo types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("reassign_to"), &reassignTo)...)
+	if resp.Diagnostics.HasError() || reassignTo.IsUnknown() || reassignTo.IsNull() {
+		return
+	}
+
+	if reassignTo.Equal(id) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("reassign_to"),
+			"Invalid reassign_to",
+			"reassign_to must not be the category itself, as its content is deleted with it.",
+		)
+	}
+}
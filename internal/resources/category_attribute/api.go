@@ -3,11 +3,13 @@ package category_attribute
 import (
 	"context"
 	"net/http"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/labd/bluestonepim-go-sdk/pim"
+	"github.com/labd/terraform-provider-bluestonepim/internal/resources/category"
 	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
 )
 
@@ -16,18 +18,11 @@ func GetCategoryAttributeByID(
 	client pim.ClientWithResponsesInterface,
 	categoryId, attributeId string,
 ) (*CategoryAttribute, diag.Diagnostic) {
-	response, err := client.ListAttributesAttachedToGivenNodeWithResponse(
-		ctx, categoryId, &pim.ListAttributesAttachedToGivenNodeParams{})
-
-	if err != nil {
-		return nil, diag.NewErrorDiagnostic("Unable to read data", err.Error())
-	}
-
-	if d := utils.AssertStatusCode(response, http.StatusOK); d != nil {
+	resources, d := category.ListCategoryAttributes(ctx, client, categoryId)
+	if d != nil {
 		return nil, d
 	}
 
-	resources := *response.JSON200.Data
 	if len(resources) == 0 {
 		return nil, nil
 	}
@@ -54,6 +49,13 @@ func GetCategoryAttributeByID(
 			result.Mandatory = types.BoolValue(false)
 		}
 
+		// Attributes without a default value have an empty value
+		if resource.AttributeValue != nil && *resource.AttributeValue != "" {
+			result.DefaultValue = types.StringValue(*resource.AttributeValue)
+		} else {
+			result.DefaultValue = types.StringNull()
+		}
+
 		return result, nil
 	}
 
@@ -67,14 +69,25 @@ func UpdateAttributeDefinition(
 	resource *CategoryAttribute,
 ) (*CategoryAttribute, diag.Diagnostic) {
 
-	if resource.Mandatory.ValueBool() != current.Mandatory.ValueBool() {
+	mandatoryChanged := resource.Mandatory.ValueBool() != current.Mandatory.ValueBool()
+	defaultValueChanged := !resource.DefaultValue.Equal(current.DefaultValue)
+	if mandatoryChanged || defaultValueChanged {
+		body := pim.UpdateNodeAttributeValueJSONRequestBody{
+			Mandatory: resource.Mandatory.ValueBoolPointer(),
+		}
+		if defaultValueChanged {
+			body.AttributeValue = &pim.PropertyUpdateString{
+				Value: utils.Ref(resource.DefaultValue.ValueString()),
+			}
+		}
+
 		response, err := client.UpdateNodeAttributeValueWithResponse(ctx,
 			current.CategoryId.ValueString(),
 			current.AttributeDefinitionId.ValueString(),
-			nil,
-			pim.UpdateNodeAttributeValueJSONRequestBody{
-				Mandatory: resource.Mandatory.ValueBoolPointer(),
+			&pim.UpdateNodeAttributeValueParams{
+				ForceCla: utils.Ref(resource.forceClassification()),
 			},
+			body,
 		)
 		if err != nil {
 			return nil, diag.NewErrorDiagnostic("Unable to update category", err.Error())
@@ -85,24 +98,33 @@ func UpdateAttributeDefinition(
 		}
 	}
 
-	return GetCategoryAttributeByID(
-		ctx, client, current.CategoryId.ValueString(), current.AttributeDefinitionId.ValueString())
+	// The update is processed asynchronously
+	return waitForCategoryAttribute(ctx, client, current.CategoryId.ValueString(), current.AttributeDefinitionId.ValueString(),
+		"the attribute definition to be updated",
+		func(result *CategoryAttribute) bool {
+			return result != nil &&
+				result.Mandatory.ValueBool() == resource.Mandatory.ValueBool() &&
+				result.DefaultValue.Equal(resource.DefaultValue)
+		})
 }
 
+// AssignAttributeDefinition assigns the attribute definition to the category
+// and waits until the assignment is processed. onCreated is called once the
+// assignment is accepted.
 func AssignAttributeDefinition(
 	ctx context.Context,
 	client pim.ClientWithResponsesInterface,
 	resource *CategoryAttribute,
+	onCreated utils.OnCreated,
 ) (*CategoryAttribute, diag.Diagnostic) {
-
 	response, err := client.CreateCatalogNodeAttributeWithResponse(ctx,
 		resource.CategoryId.ValueString(),
 		resource.AttributeDefinitionId.ValueString(),
 		&pim.CreateCatalogNodeAttributeParams{
-			ForceCla: utils.Ref(true),
+			ForceCla: utils.Ref(resource.forceClassification()),
 		},
 		pim.CreateCatalogNodeAttributeJSONRequestBody{
-			Value: utils.Ref(""),
+			Value: utils.Ref(resource.DefaultValue.ValueString()),
 		},
 	)
 
@@ -114,11 +136,18 @@ func AssignAttributeDefinition(
 	if d := utils.AssertStatusCode(response, http.StatusAccepted); d != nil {
 		return nil, d
 	}
+	if d := onCreated(resource.AttributeDefinitionId.ValueString()); d != nil {
+		return nil, d
+	}
 
-	current := &CategoryAttribute{
-		CategoryId:            resource.CategoryId,
-		AttributeDefinitionId: resource.AttributeDefinitionId,
+	// The assignment is processed asynchronously
+	current, d := waitForCategoryAttribute(ctx, client, resource.CategoryId.ValueString(), resource.AttributeDefinitionId.ValueString(),
+		"the attribute definition to be assigned",
+		func(result *CategoryAttribute) bool { return result != nil })
+	if d != nil {
+		return nil, d
 	}
+	current.DefaultValue = resource.DefaultValue
 
 	// We call the update here since the API doesn't support setting certain flags
 	// on creation.
@@ -139,5 +168,165 @@ func UnassignAttributeDefinition(
 		return d
 	}
 
+	_, d := waitForCategoryAttribute(ctx, client, categoryId, attributeId,
+		"the attribute definition to be removed from the category",
+		func(result *CategoryAttribute) bool { return result == nil })
+	return d
+}
+
+// waitForCategoryAttribute reads the assignment until done reports the change
+// is visible, and returns the last result.
+func waitForCategoryAttribute(
+	ctx context.Context,
+	client pim.ClientWithResponsesInterface,
+	categoryId, attributeId string,
+	description string,
+	done func(result *CategoryAttribute) bool,
+) (*CategoryAttribute, diag.Diagnostic) {
+	var result *CategoryAttribute
+	d := utils.WaitFor(ctx, description, func(ctx context.Context) (bool, diag.Diagnostic) {
+		var d diag.Diagnostic
+		result, d = GetCategoryAttributeByID(ctx, client, categoryId, attributeId)
+		if d != nil {
+			return false, d
+		}
+		return done(result), nil
+	})
+	return result, d
+}
+
+// removeProductValuesPageSize is the number of products of which the values
+// are removed in a single request.
+const removeProductValuesPageSize = 1000
+
+// RemoveProductValues removes the values of the attribute definition from
+// the products in the category and its subcategories that lost the attribute
+// when it was unassigned. Products that still have the attribute through
+// another category keep their values, so it must be called after the
+// attribute is unassigned.
+func RemoveProductValues(ctx context.Context, client pim.ClientWithResponsesInterface, categoryId, attributeId string) diag.Diagnostic {
+	productIds, d := listSubtreeProductIds(ctx, client, categoryId)
+	if d != nil {
+		return d
+	}
+
+	// Whether a category has the attribute, assigned or inherited
+	assigned := map[string]bool{}
+	hasAttribute := func(categoryId string) (bool, diag.Diagnostic) {
+		if result, ok := assigned[categoryId]; ok {
+			return result, nil
+		}
+		attributes, d := category.ListCategoryAttributes(ctx, client, categoryId)
+		if d != nil {
+			return false, d
+		}
+		assigned[categoryId] = slices.ContainsFunc(attributes, func(a pim.CategoryAttributeMetadataResponse) bool {
+			return a.AttributeDefinitionId != nil && *a.AttributeDefinitionId == attributeId
+		})
+		return assigned[categoryId], nil
+	}
+
+	for ids := range slices.Chunk(productIds, removeProductValuesPageSize) {
+		products, d := listProductCategories(ctx, client, ids)
+		if d != nil {
+			return d
+		}
+
+		remove := make([]string, 0, len(ids))
+		for _, id := range ids {
+			keep := false
+			for _, categoryId := range products[id] {
+				if keep, d = hasAttribute(categoryId); d != nil {
+					return d
+				}
+				if keep {
+					break
+				}
+			}
+			if !keep {
+				remove = append(remove, id)
+			}
+		}
+		if len(remove) == 0 {
+			continue
+		}
+
+		response, err := client.DeleteProductsAttributeWithResponse(ctx, pim.DeleteProductsAttributeJSONRequestBody{
+			AttributeDefinitionId: utils.Ref(attributeId),
+			ProductIds:            remove,
+		})
+		if err != nil {
+			return diag.NewErrorDiagnostic("Unable to remove product values", err.Error())
+		}
+
+		if d := utils.AssertStatusCode(response, http.StatusNoContent); d != nil {
+			return d
+		}
+	}
+
 	return nil
 }
+
+// listProductCategories returns the ids of the categories of each of the
+// products.
+func listProductCategories(ctx context.Context, client pim.ClientWithResponsesInterface, productIds []string) (map[string][]string, diag.Diagnostic) {
+	var view pim.ProductIdListViewsRequestDto_Views_Item
+	if err := view.FromCategoriesFilteringViewsDto(pim.CategoriesFilteringViewsDto{Type: "CATEGORIES"}); err != nil {
+		return nil, diag.NewErrorDiagnostic("Unable to list product categories", err.Error())
+	}
+
+	response, err := client.ListProductsViewsByIdsWithResponse(ctx, &pim.ListProductsViewsByIdsParams{}, pim.ListProductsViewsByIdsJSONRequestBody{
+		Ids:   productIds,
+		Views: &[]pim.ProductIdListViewsRequestDto_Views_Item{view},
+	})
+	if err != nil {
+		return nil, diag.NewErrorDiagnostic("Unable to list product categories", err.Error())
+	}
+	if d := utils.AssertStatusCode(response, http.StatusOK); d != nil {
+		return nil, d
+	}
+
+	result := make(map[string][]string, len(productIds))
+	for _, product := range utils.Items(response.JSON200.Data) {
+		if product.Id != nil {
+			result[*product.Id] = utils.Items(product.Categories)
+		}
+	}
+	return result, nil
+}
+
+// listSubtreeProductIds returns the ids of the products in the category and
+// all of its subcategories.
+func listSubtreeProductIds(ctx context.Context, client pim.ClientWithResponsesInterface, categoryId string) ([]string, diag.Diagnostic) {
+	var productIds []string
+	seen := map[string]bool{}
+
+	categoryIds := []string{categoryId}
+	for len(categoryIds) > 0 {
+		id := categoryIds[0]
+		categoryIds = categoryIds[1:]
+
+		products, d := category.ListCategoryProducts(ctx, client, id)
+		if d != nil {
+			return nil, d
+		}
+		for _, product := range products {
+			if product.ProductId != nil && !seen[*product.ProductId] {
+				seen[*product.ProductId] = true
+				productIds = append(productIds, *product.ProductId)
+			}
+		}
+
+		children, d := category.ListCategoryChildren(ctx, client, id)
+		if d != nil {
+			return nil, d
+		}
+		for _, child := range children {
+			if child.Id != nil {
+				categoryIds = append(categoryIds, *child.Id)
+			}
+		}
+	}
+
+	return productIds, nil
+}
@@ -0,0 +1,102 @@
+package category_attribute
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/labd/bluestonepim-go-sdk/pim"
+)
+
+// fakeProducts serves the category and product endpoints used to remove
+// product values. The attributes of a category include the inherited ones.
+type fakeProducts struct {
+	children   map[string][]string
+	products   map[string][]string
+	attributes map[string][]string
+	removed    []string
+}
+
+func (f *fakeProducts) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+
+	switch {
+	case r.Method == http.MethodGet && len(segments) == 4 && segments[3] == "children":
+		data := []pim.CategoryBasicResponse{}
+		for _, child := range f.children[segments[2]] {
+			data = append(data, pim.CategoryBasicResponse{Id: &child})
+		}
+		writeJSON(w, pim.ListableCategoryBasicResponse{Data: &data})
+	case r.Method == http.MethodGet && len(segments) == 4 && segments[3] == "products":
+		data := []pim.CategoryProductResponse{}
+		for _, product := range f.products[segments[2]] {
+			data = append(data, pim.CategoryProductResponse{ProductId: &product})
+		}
+		writeJSON(w, pim.ListableCategoryProductResponse{Data: &data})
+	case r.Method == http.MethodGet && len(segments) == 4 && segments[3] == "attributes":
+		data := []pim.CategoryAttributeMetadataResponse{}
+		for _, attribute := range f.attributes[segments[2]] {
+			data = append(data, pim.CategoryAttributeMetadataResponse{AttributeDefinitionId: &attribute})
+		}
+		writeJSON(w, pim.ListableCategoryAttributeMetadataResponse{Data: &data})
+	case r.Method == http.MethodPost && r.URL.Path == "/products/list/views/by-ids":
+		var body pim.ProductIdListViewsRequestDto
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		data := []pim.ProductViewDto{}
+		for _, id := range body.Ids {
+			var categories []string
+			for category, products := range f.products {
+				if slices.Contains(products, id) {
+					categories = append(categories, category)
+				}
+			}
+			data = append(data, pim.ProductViewDto{Id: &id, Categories: &categories})
+		}
+		writeJSON(w, pim.ListableProductViewDto{Data: &data})
+	case r.Method == http.MethodDelete && r.URL.Path == "/products/attributes":
+		var body pim.DeleteProductsAttributeJSONRequestBody
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.removed = append(f.removed, body.ProductIds...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestRemoveProductValuesKeepsValuesAssignedElsewhere(t *testing.T) {
+	fake := &fakeProducts{
+		children: map[string][]string{"parent": {"child"}},
+		products: map[string][]string{
+			"parent": {"a", "b"},
+			"child":  {"c"},
+			"other":  {"b"},
+		},
+		// The attribute is already unassigned from the parent and its child
+		attributes: map[string][]string{"other": {"attribute"}},
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client, err := pim.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d := RemoveProductValues(context.Background(), client, "parent", "attribute"); d != nil {
+		t.Fatalf("unexpected error %v", d)
+	}
+
+	slices.Sort(fake.removed)
+	if !slices.Equal(fake.removed, []string{"a", "c"}) {
+		t.Errorf("expected the values of a and c to be removed, got %v", fake.removed)
+	}
+}
@@ -1,9 +1,86 @@
 package category_attribute
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/resources/attribute_definition"
+)
 
 type CategoryAttribute struct {
 	CategoryId            types.String `tfsdk:"category_id"`
 	AttributeDefinitionId types.String `tfsdk:"attribute_definition_id"`
 	Mandatory             types.Bool   `tfsdk:"mandatory"`
+	DefaultValue          types.String `tfsdk:"default_value"`
+
+	ForceClassification types.Bool     `tfsdk:"force_classification"`
+	OnUnassign          types.String   `tfsdk:"on_unassign"`
+	DeletionProtection  types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
+}
+
+// keepLocalSettings copies the settings that are not stored in Bluestone PIM
+// from source.
+func (c *CategoryAttribute) keepLocalSettings(source *CategoryAttribute) {
+	c.ForceClassification = source.ForceClassification
+	if c.ForceClassification.IsNull() {
+		c.ForceClassification = types.BoolValue(true)
+	}
+	c.OnUnassign = source.OnUnassign
+	if c.OnUnassign.IsNull() {
+		c.OnUnassign = types.StringValue(OnUnassignKeep)
+	}
+	c.DeletionProtection = source.DeletionProtection
+	c.Timeouts = source.Timeouts
+}
+
+// forceClassification returns whether the attribute is pushed to the
+// products in the category, which is the default.
+func (c *CategoryAttribute) forceClassification() bool {
+	if c.ForceClassification.IsNull() || c.ForceClassification.IsUnknown() {
+		return true
+	}
+	return c.ForceClassification.ValueBool()
+}
+
+// CategorySchema describes the category schema data source data model.
+type CategorySchema struct {
+	CategoryId types.String      `tfsdk:"category_id"`
+	Attributes []SchemaAttribute `tfsdk:"attributes"`
+	JSON       types.String      `tfsdk:"json"`
+}
+
+// SchemaAttribute is an attribute of a category, assigned on the category
+// itself or inherited from a parent.
+type SchemaAttribute struct {
+	AttributeDefinitionId types.String        `tfsdk:"attribute_definition_id"`
+	Name                  types.String        `tfsdk:"name"`
+	Number                types.String        `tfsdk:"number"`
+	DataType              types.String        `tfsdk:"data_type"`
+	ContentType           types.String        `tfsdk:"content_type"`
+	Unit                  types.String        `tfsdk:"unit"`
+	GroupId               types.String        `tfsdk:"group_id"`
+	Group                 types.String        `tfsdk:"group"`
+	Mandatory             types.Bool          `tfsdk:"mandatory"`
+	Inherited             types.Bool          `tfsdk:"inherited"`
+	AssignedOn            types.String        `tfsdk:"assigned_on"`
+	Restrictions          *SchemaRestrictions `tfsdk:"restrictions"`
+}
+
+type SchemaRestrictions struct {
+	Enum  *SchemaEnumRestriction                 `tfsdk:"enum"`
+	Range *attribute_definition.RangeRestriction `tfsdk:"range"`
+	Text  *attribute_definition.TextRestriction  `tfsdk:"text"`
+}
+
+type SchemaEnumRestriction struct {
+	Type   types.String      `tfsdk:"type"`
+	Values []SchemaEnumValue `tfsdk:"values"`
+}
+
+type SchemaEnumValue struct {
+	Number   types.String `tfsdk:"number"`
+	Value    types.String `tfsdk:"value"`
+	Metadata types.String `tfsdk:"metadata"`
+	ValueId  types.String `tfsdk:"value_id"`
 }
@@ -2,13 +2,19 @@ package category_attribute
 
 import (
 	"context"
+	"fmt"
 	"github.com/labd/bluestonepim-go-sdk/pim"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
+	"github.com/labd/terraform-provider-bluestonepim/internal/resources/attribute_definition"
 	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
 )
 
@@ -17,6 +23,15 @@ var (
 	_ resource.Resource                = &Resource{}
 	_ resource.ResourceWithConfigure   = &Resource{}
 	_ resource.ResourceWithImportState = &Resource{}
+	_ resource.ResourceWithModifyPlan  = &Resource{}
+)
+
+const (
+	// OnUnassignKeep keeps the values of the products in the category
+	OnUnassignKeep = "keep"
+	// OnUnassignRemove removes the values of the products in the category
+	// and its subcategories
+	OnUnassignRemove = "remove"
 )
 
 func NewResource() resource.Resource {
@@ -24,7 +39,8 @@ func NewResource() resource.Resource {
 }
 
 type Resource struct {
-	client pim.ClientWithResponsesInterface
+	client             pim.ClientWithResponsesInterface
+	deletionProtection bool
 }
 
 // Metadata returns the data source type name.
@@ -33,7 +49,7 @@ func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, res
 }
 
 // Schema defines the schema for the data source.
-func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *Resource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "",
 		Attributes: map[string]schema.Attribute{
@@ -51,6 +67,38 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 				Optional:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"default_value": schema.StringAttribute{
+				MarkdownDescription: "Default value of the attribute for products in the category. The value " +
+					"is validated against the data type and restrictions of the attribute definition. A " +
+					"`single_select` default must be one of the enum values, and a `multi_select` default a " +
+					"comma separated list of them. `location` defaults are not checked.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"force_classification": schema.BoolAttribute{
+				MarkdownDescription: "Add the attribute to all products in the category when it is assigned " +
+					"or updated. When false, only the category is changed.",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"on_unassign": schema.StringAttribute{
+				MarkdownDescription: "What to do with the values of the products in the category and its " +
+					"subcategories when the attribute is unassigned. One of `keep` or `remove`. With `remove`, " +
+					"products that still have the attribute through another category keep their values.",
+				Computed: true,
+				Optional: true,
+				Default:  stringdefault.StaticString(OnUnassignKeep),
+				Validators: []validator.String{
+					stringvalidator.OneOf(OnUnassignKeep, OnUnassignRemove),
+				},
+			},
+			"deletion_protection": utils.DeletionProtectionAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": utils.TimeoutsBlock(ctx),
 		},
 	}
 }
@@ -68,6 +116,7 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, r
 	}
 
 	r.client = data.PimClient
+	r.deletionProtection = data.DeletionProtection
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -79,12 +128,31 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
-	result, diag := AssignAttributeDefinition(ctx, r.client, &plan)
+	timeout, diags := plan.Timeouts.Create(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, diag := AssignAttributeDefinition(ctx, r.client, &plan, utils.SaveCreatedID(ctx, &resp.State, plan, path.Empty()))
 	if diag != nil {
 		resp.Diagnostics.Append(diag)
 		return
 	}
 
+	if result == nil {
+		resp.Diagnostics.AddError(
+			"Failed assigning attribute definition",
+			fmt.Sprintf("Attribute definition %s was not found on category %s after assigning it",
+				plan.AttributeDefinitionId.ValueString(), plan.CategoryId.ValueString()),
+		)
+		return
+	}
+
+	result.keepLocalSettings(&plan)
+
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, result)
 	resp.Diagnostics.Append(diags...)
@@ -109,6 +177,14 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
+	// The attribute was unassigned outside of Terraform
+	if result == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	result.keepLocalSettings(&current)
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, result)
 	resp.Diagnostics.Append(diags...)
@@ -127,6 +203,14 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	timeout, diags := plan.Timeouts.Update(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	// Retrieve values from state
 	var state CategoryAttribute
 	diags = req.State.Get(ctx, &state)
@@ -141,6 +225,17 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	if result == nil {
+		resp.Diagnostics.AddError(
+			"Failed assigning attribute definition",
+			fmt.Sprintf("Attribute definition %s was not found on category %s after assigning it",
+				plan.AttributeDefinitionId.ValueString(), plan.CategoryId.ValueString()),
+		)
+		return
+	}
+
+	result.keepLocalSettings(&plan)
+
 	diags = resp.State.Set(ctx, result)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -158,14 +253,84 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
+	timeout, diags := state.Timeouts.Delete(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if d := utils.CheckDeletionProtection(state.DeletionProtection, r.deletionProtection, "attribute definition "+state.AttributeDefinitionId.ValueString()+" of category "+state.CategoryId.ValueString()); d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+
 	diag := UnassignAttributeDefinition(ctx, r.client, state.CategoryId.ValueString(), state.AttributeDefinitionId.ValueString())
 	if diag != nil {
 		resp.Diagnostics.Append(diag)
 		return
 	}
+
+	if state.OnUnassign.ValueString() == OnUnassignRemove {
+		if d := RemoveProductValues(ctx, r.client, state.CategoryId.ValueString(), state.AttributeDefinitionId.ValueString()); d != nil {
+			resp.Diagnostics.Append(d)
+			return
+		}
+	}
+}
+
+// ModifyPlan validates the default value against the attribute definition.
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan CategoryAttribute
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.DefaultValue.IsNull() || plan.DefaultValue.IsUnknown() || plan.AttributeDefinitionId.IsUnknown() {
+		return
+	}
+
+	definition, d := attribute_definition.GetAttributeDefinitionResponse(ctx, r.client, plan.AttributeDefinitionId.ValueString())
+	if d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+	if definition == nil || definition.DataType == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_value"),
+			"Unable to validate default value",
+			fmt.Sprintf("Attribute definition %s was not found or has no data type.", plan.AttributeDefinitionId.ValueString()),
+		)
+		return
+	}
+
+	if err := attribute_definition.ValidateValue(string(*definition.DataType), definition.Restrictions, plan.DefaultValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_value"),
+			"Invalid default value",
+			err.Error(),
+		)
+	}
 }
 
+// ImportState imports the assignment by an ID of the form
+// <category_id>:<attribute_definition_id>.
 func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	categoryId, attributeId, found := strings.Cut(req.ID, ":")
+	if !found || categoryId == "" || attributeId == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an import ID of the form <category_id>:<attribute_definition_id>, got %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("category_id"), categoryId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("attribute_definition_id"), attributeId)...)
 }
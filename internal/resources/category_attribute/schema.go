@@ -0,0 +1,227 @@
+package category_attribute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/bluestonepim-go-sdk/pim"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/resources/attribute_definition"
+	"github.com/labd/terraform-provider-bluestonepim/internal/resources/category"
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// GetCategorySchema returns all attributes of the category, the ones assigned
+// on the category and the ones inherited from its parents, together with
+// their attribute definitions.
+func GetCategorySchema(ctx context.Context, client pim.ClientWithResponsesInterface, categoryId string) (*CategorySchema, diag.Diagnostic) {
+	assigned, d := category.ListCategoryAttributes(ctx, client, categoryId)
+	if d != nil {
+		return nil, d
+	}
+
+	var attributes []pim.CategoryAttributeMetadataResponse
+	for _, attribute := range assigned {
+		if attribute.AttributeDefinitionId != nil {
+			attributes = append(attributes, attribute)
+		}
+	}
+
+	ids := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		ids = append(ids, *attribute.AttributeDefinitionId)
+	}
+	definitions, d := findAttributeDefinitions(ctx, client, ids)
+	if d != nil {
+		return nil, d
+	}
+
+	result := &CategorySchema{
+		CategoryId: types.StringValue(categoryId),
+		Attributes: make([]SchemaAttribute, 0, len(attributes)),
+	}
+	for _, attribute := range attributes {
+		definition, ok := definitions[*attribute.AttributeDefinitionId]
+		if !ok {
+			return nil, diag.NewErrorDiagnostic(
+				"Attribute definition not found",
+				fmt.Sprintf("Attribute definition %s of category %s does not exist.", *attribute.AttributeDefinitionId, categoryId),
+			)
+		}
+
+		assignedOn := categoryId
+		if attribute.AssignedOn != nil {
+			assignedOn = *attribute.AssignedOn
+		}
+
+		var dataType *string
+		if definition.DataType != nil {
+			dataType = utils.Ref(string(*definition.DataType))
+		}
+
+		result.Attributes = append(result.Attributes, SchemaAttribute{
+			AttributeDefinitionId: types.StringValue(*attribute.AttributeDefinitionId),
+			Name:                  types.StringValue(definition.Name),
+			Number:                types.StringPointerValue(definition.Number),
+			DataType:              types.StringPointerValue(dataType),
+			ContentType:           types.StringPointerValue(definition.ContentType),
+			Unit:                  types.StringPointerValue(definition.Unit),
+			GroupId:               types.StringPointerValue(definition.GroupId),
+			Group:                 types.StringPointerValue(definition.Group),
+			Mandatory:             types.BoolValue(attribute.MandatorySetOn != nil),
+			Inherited:             types.BoolValue(assignedOn != categoryId),
+			AssignedOn:            types.StringValue(assignedOn),
+			Restrictions:          fromRestrictionsDto(definition.Restrictions),
+		})
+	}
+
+	document, err := json.MarshalIndent(schemaDocument(result), "", "  ")
+	if err != nil {
+		return nil, diag.NewErrorDiagnostic("Unable to encode category schema", err.Error())
+	}
+	result.JSON = types.StringValue(string(document))
+
+	return result, nil
+}
+
+// findAttributeDefinitions returns the attribute definitions with the given
+// ids, keyed by id.
+func findAttributeDefinitions(ctx context.Context, client pim.ClientWithResponsesInterface, ids []string) (map[string]pim.AttributeDefinitionResponse, diag.Diagnostic) {
+	definitions := make(map[string]pim.AttributeDefinitionResponse, len(ids))
+	if len(ids) == 0 {
+		return definitions, nil
+	}
+
+	found, d := utils.CollectPages(ctx, utils.DefaultPageSize, func(ctx context.Context, page utils.Page) ([]pim.AttributeDefinitionResponse, diag.Diagnostic) {
+		res, err := client.FindFilteredAttributeDefinitionsWithResponse(ctx, nil, pim.FindFilteredAttributeDefinitionsJSONRequestBody{
+			Filters: &[]pim.AttributeDefinitionFilterDto{
+				{
+					Type:   utils.Ref(pim.AttributeDefinitionFilterDtoTypeIDIN),
+					Values: &ids,
+				},
+			},
+			Page:     utils.Ref(int32(page.Number)),
+			PageSize: utils.Ref(int32(page.Size)),
+		})
+		if err != nil {
+			return nil, diag.NewErrorDiagnostic("Unable to read attribute definitions", err.Error())
+		}
+		if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+			return nil, d
+		}
+		return utils.Items(res.JSON200.Data), nil
+	})
+	if d != nil {
+		return nil, d
+	}
+
+	for _, definition := range found {
+		if definition.Id != nil {
+			definitions[*definition.Id] = definition
+		}
+	}
+	return definitions, nil
+}
+
+// fromRestrictionsDto converts the restrictions of an attribute definition.
+// Unlike the attribute definition resource, the enum values are listed in the
+// order of Bluestone PIM.
+func fromRestrictionsDto(restrictions *pim.RestrictionsDto) *SchemaRestrictions {
+	if restrictions == nil || (restrictions.Enum == nil && restrictions.Range == nil && restrictions.Text == nil) {
+		return nil
+	}
+
+	// The range and text restrictions are the same as on the attribute
+	// definition resource
+	converted := attribute_definition.FromRestrictionsDto(restrictions)
+	result := &SchemaRestrictions{
+		Range: converted.Range,
+		Text:  converted.Text,
+	}
+
+	if restrictions.Enum != nil {
+		result.Enum = &SchemaEnumRestriction{
+			Type:   types.StringPointerValue(restrictions.Enum.Type),
+			Values: []SchemaEnumValue{},
+		}
+		if restrictions.Enum.Values != nil {
+			for _, v := range *restrictions.Enum.Values {
+				result.Enum.Values = append(result.Enum.Values, SchemaEnumValue{
+					Number:   types.StringPointerValue(v.Number),
+					Value:    types.StringValue(v.Value),
+					Metadata: types.StringPointerValue(v.Metadata),
+					ValueId:  types.StringPointerValue(v.ValueId),
+				})
+			}
+		}
+	}
+
+	return result
+}
+
+// schemaDocument returns the category schema as plain values for the json
+// attribute. It uses the same names as the structured attributes.
+func schemaDocument(schema *CategorySchema) map[string]any {
+	attributes := make([]map[string]any, 0, len(schema.Attributes))
+	for _, a := range schema.Attributes {
+		attribute := map[string]any{
+			"attribute_definition_id": a.AttributeDefinitionId.ValueString(),
+			"name":                    a.Name.ValueString(),
+			"number":                  a.Number.ValueStringPointer(),
+			"data_type":               a.DataType.ValueStringPointer(),
+			"content_type":            a.ContentType.ValueStringPointer(),
+			"unit":                    a.Unit.ValueStringPointer(),
+			"group_id":                a.GroupId.ValueStringPointer(),
+			"group":                   a.Group.ValueStringPointer(),
+			"mandatory":               a.Mandatory.ValueBool(),
+			"inherited":               a.Inherited.ValueBool(),
+			"assigned_on":             a.AssignedOn.ValueString(),
+			"restrictions":            nil,
+		}
+
+		if r := a.Restrictions; r != nil {
+			restrictions := map[string]any{"enum": nil, "range": nil, "text": nil}
+			if r.Enum != nil {
+				values := make([]map[string]any, 0, len(r.Enum.Values))
+				for _, v := range r.Enum.Values {
+					values = append(values, map[string]any{
+						"number":   v.Number.ValueStringPointer(),
+						"value":    v.Value.ValueString(),
+						"metadata": v.Metadata.ValueStringPointer(),
+						"value_id": v.ValueId.ValueStringPointer(),
+					})
+				}
+				restrictions["enum"] = map[string]any{
+					"type":   r.Enum.Type.ValueStringPointer(),
+					"values": values,
+				}
+			}
+			if r.Range != nil {
+				restrictions["range"] = map[string]any{
+					"min":  r.Range.Min.ValueStringPointer(),
+					"max":  r.Range.Max.ValueStringPointer(),
+					"step": r.Range.Step.ValueStringPointer(),
+				}
+			}
+			if r.Text != nil {
+				restrictions["text"] = map[string]any{
+					"max_length":  r.Text.MaxLength.ValueInt32Pointer(),
+					"pattern":     r.Text.Pattern.ValueStringPointer(),
+					"whitespaces": r.Text.Whitespaces.ValueBoolPointer(),
+				}
+			}
+			attribute["restrictions"] = restrictions
+		}
+
+		attributes = append(attributes, attribute)
+	}
+
+	return map[string]any{
+		"category_id": schema.CategoryId.ValueString(),
+		"attributes":  attributes,
+	}
+}
@@ -0,0 +1,209 @@
+package category_attribute
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/labd/bluestonepim-go-sdk/pim"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &SchemaDataSource{}
+
+func NewSchemaDataSource() datasource.DataSource {
+	return &SchemaDataSource{}
+}
+
+// SchemaDataSource defines the category schema data source implementation.
+type SchemaDataSource struct {
+	client pim.ClientWithResponsesInterface
+}
+
+func (d *SchemaDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_category_schema"
+}
+
+func (d *SchemaDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the effective attribute schema of a category: every attribute assigned on " +
+			"the category or inherited from its parents, with the details of its attribute definition. The " +
+			"schema is also available as a JSON document in `json`, for example to generate typed models " +
+			"from a Terraform output.",
+		Attributes: map[string]schema.Attribute{
+			"category_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the category.",
+				Required:            true,
+			},
+			"attributes": schema.ListNestedAttribute{
+				MarkdownDescription: "The attributes of the category.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"attribute_definition_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the attribute definition.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the attribute definition.",
+							Computed:            true,
+						},
+						"number": schema.StringAttribute{
+							MarkdownDescription: "The number of the attribute definition.",
+							Computed:            true,
+						},
+						"data_type": schema.StringAttribute{
+							MarkdownDescription: "The data type of the attribute.",
+							Computed:            true,
+						},
+						"content_type": schema.StringAttribute{
+							MarkdownDescription: "The content type of the attribute.",
+							Computed:            true,
+						},
+						"unit": schema.StringAttribute{
+							MarkdownDescription: "The unit of the attribute.",
+							Computed:            true,
+						},
+						"group_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the attribute group.",
+							Computed:            true,
+						},
+						"group": schema.StringAttribute{
+							MarkdownDescription: "The name of the attribute group.",
+							Computed:            true,
+						},
+						"mandatory": schema.BoolAttribute{
+							MarkdownDescription: "Whether the attribute is mandatory in the category.",
+							Computed:            true,
+						},
+						"inherited": schema.BoolAttribute{
+							MarkdownDescription: "Whether the attribute is inherited from a parent category.",
+							Computed:            true,
+						},
+						"assigned_on": schema.StringAttribute{
+							MarkdownDescription: "The ID of the category the attribute is assigned on.",
+							Computed:            true,
+						},
+						"restrictions": restrictionsAttribute(),
+					},
+				},
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "The category ID and attributes as a JSON document, using the same names " +
+					"as the attributes of this data source.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func restrictionsAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "The restrictions of the attribute definition.",
+		Computed:            true,
+		Attributes: map[string]schema.Attribute{
+			"enum": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						MarkdownDescription: "The type of the enum values.",
+						Computed:            true,
+					},
+					"values": schema.ListNestedAttribute{
+						MarkdownDescription: "The values of the enum, in the order of Bluestone PIM.",
+						Computed:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"number": schema.StringAttribute{
+									MarkdownDescription: "The number of the value.",
+									Computed:            true,
+								},
+								"value": schema.StringAttribute{
+									MarkdownDescription: "The value.",
+									Computed:            true,
+								},
+								"metadata": schema.StringAttribute{
+									MarkdownDescription: "The metadata of the value.",
+									Computed:            true,
+								},
+								"value_id": schema.StringAttribute{
+									MarkdownDescription: "The ID of the value.",
+									Computed:            true,
+								},
+							},
+						},
+					},
+				},
+			},
+			"range": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"min": schema.StringAttribute{
+						MarkdownDescription: "The minimum value.",
+						Computed:            true,
+					},
+					"max": schema.StringAttribute{
+						MarkdownDescription: "The maximum value.",
+						Computed:            true,
+					},
+					"step": schema.StringAttribute{
+						MarkdownDescription: "The step between values.",
+						Computed:            true,
+					},
+				},
+			},
+			"text": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"max_length": schema.Int32Attribute{
+						MarkdownDescription: "The maximum length of the text.",
+						Computed:            true,
+					},
+					"pattern": schema.StringAttribute{
+						MarkdownDescription: "The pattern of the text.",
+						Computed:            true,
+					},
+					"whitespaces": schema.BoolAttribute{
+						MarkdownDescription: "Whether the text allows whitespaces.",
+						Computed:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *SchemaDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, diag := utils.GetProviderData(req.ProviderData)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	d.client = data.PimClient
+}
+
+func (d *SchemaDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CategorySchema
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, diag := GetCategorySchema(ctx, d.client, data.CategoryId.ValueString())
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
+}
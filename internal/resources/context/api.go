@@ -8,8 +8,12 @@ import (
 	"github.com/labd/bluestonepim-go-sdk/global_settings"
 	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
 	"net/http"
+	"net/url"
+	"path"
 )
 
+const ResourceIdHeader = "Resource-Id"
+
 func GetContextByID(
 	ctx context.Context,
 	client *global_settings.ClientWithResponses,
@@ -25,17 +29,39 @@ func GetContextByID(
 	return &Context{
 		ID:         types.StringValue(contextRes.JSON200.Id),
 		Name:       types.StringValue(contextRes.JSON200.Name),
-		Locale:     types.StringValue(contextRes.JSON200.Locale),
+		Locale:     utils.NewLocaleValue(contextRes.JSON200.Locale),
 		FallbackID: types.StringPointerValue(contextRes.JSON200.Fallback),
+		// Not returned by the API, the resource keeps the configured value
+		AdoptArchived: types.BoolValue(false),
 	}, nil
 }
 
+// CreateContext creates the context. When an archived context with the same
+// locale exists, creation fails unless AdoptArchived is set, in which case the
+// archived context is updated to the planned values and adopted instead.
+// onCreated is called with the id of a new context before it is read back.
 func CreateContext(
 	ctx context.Context,
-	//We need the implementation to do Create instead of CreateWithResponse, as the API returns an invalid response when creating a context
 	client *global_settings.ClientWithResponses,
 	current *Context,
+	onCreated utils.OnCreated,
 ) (*Context, diag.Diagnostic) {
+	archived, d := findArchivedContext(ctx, client, current.Locale.ValueString())
+	if d != nil {
+		return nil, d
+	}
+	if archived != nil {
+		if !current.AdoptArchived.ValueBool() {
+			return nil, diag.NewErrorDiagnostic(
+				"Archived context exists",
+				fmt.Sprintf("An archived context %s (%s) with locale %s already exists. Restore it in Bluestone PIM "+
+					"and import it, or set adopt_archived to true to adopt it.", archived.Id, archived.Name, archived.Locale),
+			)
+		}
+		return adoptArchivedContext(ctx, client, archived.Id, current)
+	}
+
+	// We need the implementation to do Create instead of CreateWithResponse, as the API returns an invalid response when creating a context
 	contextRes, err := client.Create(ctx, global_settings.CreateJSONRequestBody{
 		Fallback: current.FallbackID.ValueStringPointer(),
 		Locale:   current.Locale.ValueString(),
@@ -44,33 +70,137 @@ func CreateContext(
 	if err != nil {
 		return nil, diag.NewErrorDiagnostic("Failed creating context", err.Error())
 	}
+	defer contextRes.Body.Close()
 
 	if contextRes.StatusCode != http.StatusCreated {
 		return nil, diag.NewErrorDiagnostic("Failed creating context", fmt.Sprintf("unexpected status code %d", contextRes.StatusCode))
 	}
 
-	//Workaround to find the id for the newly created context based on locale
-	res, err := client.FindWithResponse(ctx, nil)
-	if err != nil {
-		return nil, diag.NewErrorDiagnostic("Failed creating context", err.Error())
+	id := createdResourceID(contextRes)
+	if id == "" {
+		id, d = findCreatedContextID(ctx, client, current)
+		if d != nil {
+			return nil, d
+		}
+	}
+	if d := onCreated(id); d != nil {
+		return nil, d
 	}
 
-	if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+	// New contexts are not always found right away
+	if d := waitForContext(ctx, client, id); d != nil {
 		return nil, d
 	}
 
-	var foundContext *global_settings.ContextResponseDto
-	for _, d := range res.JSON200.Data {
-		if d.Locale == current.Locale.ValueString() {
-			foundContext = &d
-			break
+	return GetContextByID(ctx, client, id)
+}
+
+// createdResourceID returns the id of the created context from the
+// Resource-Id header, or from the last path segment of the Location header.
+func createdResourceID(res *http.Response) string {
+	if id := res.Header.Get(ResourceIdHeader); id != "" {
+		return id
+	}
+	if location := res.Header.Get("Location"); location != "" {
+		if u, err := url.Parse(location); err == nil && u.Path != "" {
+			if id := path.Base(u.Path); id != "/" && id != "." {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+// findCreatedContextID finds the id of the created context by name and
+// locale, for when the create response does not include it. All active
+// contexts are searched.
+func findCreatedContextID(
+	ctx context.Context,
+	client *global_settings.ClientWithResponses,
+	current *Context,
+) (string, diag.Diagnostic) {
+	contexts, d := ListContexts(ctx, client)
+	if d != nil {
+		return "", d
+	}
+
+	var found []string
+	for _, c := range contexts {
+		if !c.Archived && c.Name == current.Name.ValueString() && utils.LocalesEqual(c.Locale, current.Locale.ValueString()) {
+			found = append(found, c.Id)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return "", diag.NewErrorDiagnostic(
+			"Failed finding created context",
+			fmt.Sprintf("The context with name %s and locale %s was created, but could not be found.",
+				current.Name.ValueString(), current.Locale.ValueString()),
+		)
+	case 1:
+		return found[0], nil
+	default:
+		return "", diag.NewErrorDiagnostic(
+			"Failed finding created context",
+			fmt.Sprintf("Found %d active contexts with name %s and locale %s, the created context can not be "+
+				"identified. Remove the duplicates in Bluestone PIM and import the remaining context.",
+				len(found), current.Name.ValueString(), current.Locale.ValueString()),
+		)
+	}
+}
+
+func findArchivedContext(
+	ctx context.Context,
+	client *global_settings.ClientWithResponses,
+	locale string,
+) (*global_settings.ContextResponseDto, diag.Diagnostic) {
+	contexts, d := findContexts(ctx, client, global_settings.ARCHIVE)
+	if d != nil {
+		return nil, d
+	}
+	for _, c := range contexts {
+		if c.Archived && utils.LocalesEqual(c.Locale, locale) {
+			return &c, nil
 		}
 	}
-	if foundContext == nil {
-		return nil, diag.NewErrorDiagnostic("Failed finding context in list", fmt.Sprintf("Failed finding context with locale %s in list", current.Locale.ValueString()))
+	return nil, nil
+}
+
+// adoptArchivedContext updates the archived context to the planned values.
+// The API has no endpoint to restore a context, so an update is used and the
+// result is verified.
+func adoptArchivedContext(
+	ctx context.Context,
+	client *global_settings.ClientWithResponses,
+	id string,
+	current *Context,
+) (*Context, diag.Diagnostic) {
+	updateRes, err := client.UpdateWithResponse(ctx, id, global_settings.UpdateJSONRequestBody{
+		Fallback: current.FallbackID.ValueStringPointer(),
+		Locale:   current.Locale.ValueString(),
+		Name:     current.Name.ValueString(),
+	})
+	if err != nil {
+		return nil, diag.NewErrorDiagnostic("Failed adopting archived context", err.Error())
+	}
+	if d := utils.AssertStatusCode(updateRes, http.StatusNoContent); d != nil {
+		return nil, d
+	}
+
+	c, d := getContextDto(ctx, client, id)
+	if d != nil {
+		return nil, d
+	}
+	if c.Archived {
+		return nil, diag.NewErrorDiagnostic(
+			"Failed adopting archived context",
+			fmt.Sprintf("Context %s is still archived after updating it. Restore it in Bluestone PIM and "+
+				"import it instead.", id),
+		)
 	}
 
-	return GetContextByID(ctx, client, foundContext.Id)
+	return GetContextByID(ctx, client, id)
 }
 
 func UpdateContextById(
@@ -112,3 +242,208 @@ func DeleteContextByID(
 
 	return nil
 }
+
+// ListContexts returns all active contexts.
+func ListContexts(
+	ctx context.Context,
+	client *global_settings.ClientWithResponses,
+) ([]global_settings.ContextResponseDto, diag.Diagnostic) {
+	return findContexts(ctx, client, global_settings.ACTIVE)
+}
+
+// findContexts returns the contexts in the given state. The endpoint is not
+// paged, so all contexts are returned by a single request.
+func findContexts(
+	ctx context.Context,
+	client *global_settings.ClientWithResponses,
+	state global_settings.FindParamsContextState,
+) ([]global_settings.ContextResponseDto, diag.Diagnostic) {
+	res, err := client.FindWithResponse(ctx, &global_settings.FindParams{
+		ContextState: utils.Ref(state),
+	})
+	if err != nil {
+		return nil, diag.NewErrorDiagnostic("Failed listing contexts", err.Error())
+	}
+	if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+		return nil, d
+	}
+	return res.JSON200.Data, nil
+}
+
+// FindContextData returns the context with the given id, or the active
+// context with the given locale or name, including its fallback chain.
+func FindContextData(
+	ctx context.Context,
+	client *global_settings.ClientWithResponses,
+	id string,
+	locale string,
+	name string,
+) (*ContextData, diag.Diagnostic) {
+	contexts, d := ListContexts(ctx, client)
+	if d != nil {
+		return nil, d
+	}
+
+	var search string
+	var matches func(c *global_settings.ContextResponseDto) bool
+	switch {
+	case id != "":
+		search = fmt.Sprintf("id %s", id)
+		matches = func(c *global_settings.ContextResponseDto) bool { return c.Id == id }
+	case locale != "":
+		search = fmt.Sprintf("locale %s", locale)
+		matches = func(c *global_settings.ContextResponseDto) bool { return utils.LocalesEqual(c.Locale, locale) }
+	default:
+		search = fmt.Sprintf("name %s", name)
+		matches = func(c *global_settings.ContextResponseDto) bool { return c.Name == name }
+	}
+
+	var found []global_settings.ContextResponseDto
+	for i := range contexts {
+		if matches(&contexts[i]) {
+			found = append(found, contexts[i])
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		// Archived contexts are not listed, but can still be read by id
+		if id != "" {
+			c, d := getContextDto(ctx, client, id)
+			if d != nil {
+				return nil, d
+			}
+			return toContextData(ctx, client, contexts, c)
+		}
+		return nil, diag.NewErrorDiagnostic("Context not found", fmt.Sprintf("No context found with %s", search))
+	case 1:
+		return toContextData(ctx, client, contexts, &found[0])
+	default:
+		return nil, diag.NewErrorDiagnostic(
+			"Multiple contexts found",
+			fmt.Sprintf("Found %d contexts with %s, use the id to select one", len(found), search),
+		)
+	}
+}
+
+// ListContextData returns all active contexts, including their fallback
+// chains.
+func ListContextData(
+	ctx context.Context,
+	client *global_settings.ClientWithResponses,
+) ([]ContextData, diag.Diagnostic) {
+	contexts, d := ListContexts(ctx, client)
+	if d != nil {
+		return nil, d
+	}
+
+	result := make([]ContextData, 0, len(contexts))
+	for i := range contexts {
+		data, d := toContextData(ctx, client, contexts, &contexts[i])
+		if d != nil {
+			return nil, d
+		}
+		result = append(result, *data)
+	}
+	return result, nil
+}
+
+func getContextDto(
+	ctx context.Context,
+	client *global_settings.ClientWithResponses,
+	id string,
+) (*global_settings.ContextResponseDto, diag.Diagnostic) {
+	res, err := client.GetWithResponse(ctx, id)
+	if err != nil {
+		return nil, diag.NewErrorDiagnostic("Failed fetching context", err.Error())
+	}
+	if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+		return nil, d
+	}
+	return res.JSON200, nil
+}
+
+func toContextData(
+	ctx context.Context,
+	client *global_settings.ClientWithResponses,
+	contexts []global_settings.ContextResponseDto,
+	c *global_settings.ContextResponseDto,
+) (*ContextData, diag.Diagnostic) {
+	chain, d := resolveFallbackChain(ctx, client, contexts, c)
+	if d != nil {
+		return nil, d
+	}
+
+	return &ContextData{
+		ID:            types.StringValue(c.Id),
+		Name:          types.StringValue(c.Name),
+		Locale:        types.StringValue(c.Locale),
+		FallbackID:    types.StringPointerValue(c.Fallback),
+		Initial:       types.BoolValue(c.Initial != nil && *c.Initial),
+		FallbackChain: chain,
+	}, nil
+}
+
+// resolveFallbackChain follows the fallback of the context recursively and
+// returns the contexts in the order they are used to fill gaps. Fallbacks
+// that are not in contexts, for example archived ones, are fetched by id.
+func resolveFallbackChain(
+	ctx context.Context,
+	client *global_settings.ClientWithResponses,
+	contexts []global_settings.ContextResponseDto,
+	c *global_settings.ContextResponseDto,
+) ([]FallbackChainLink, diag.Diagnostic) {
+	byID := make(map[string]*global_settings.ContextResponseDto, len(contexts))
+	for i := range contexts {
+		byID[contexts[i].Id] = &contexts[i]
+	}
+
+	chain := []FallbackChainLink{}
+	visited := map[string]bool{c.Id: true}
+	for current := c; current.Fallback != nil && *current.Fallback != ""; {
+		fallbackID := *current.Fallback
+		if visited[fallbackID] {
+			return nil, diag.NewErrorDiagnostic(
+				"Context fallback cycle",
+				fmt.Sprintf("The fallback chain of context %s loops back to context %s", c.Id, fallbackID),
+			)
+		}
+		visited[fallbackID] = true
+
+		next, ok := byID[fallbackID]
+		if !ok {
+			var d diag.Diagnostic
+			next, d = getContextDto(ctx, client, fallbackID)
+			if d != nil {
+				return nil, d
+			}
+			byID[fallbackID] = next
+		}
+
+		chain = append(chain, FallbackChainLink{
+			ID:     types.StringValue(next.Id),
+			Name:   types.StringValue(next.Name),
+			Locale: types.StringValue(next.Locale),
+		})
+		current = next
+	}
+	return chain, nil
+}
+
+// waitForContext waits until the created context is found.
+func waitForContext(
+	ctx context.Context,
+	client *global_settings.ClientWithResponses,
+	id string,
+) diag.Diagnostic {
+	return utils.WaitFor(ctx, "context "+id+" to be created", func(ctx context.Context) (bool, diag.Diagnostic) {
+		res, err := client.GetWithResponse(ctx, id)
+		if err != nil {
+			return false, diag.NewErrorDiagnostic("Failed fetching context", err.Error())
+		}
+		if utils.IsNotFound(res) {
+			return false, nil
+		}
+		return true, utils.AssertStatusCode(res, http.StatusOK)
+	})
+}
@@ -0,0 +1,131 @@
+package context
+
+import (
+	"context"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/labd/bluestonepim-go-sdk/global_settings"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DataSource{}
+
+func NewDataSource() datasource.DataSource {
+	return &DataSource{}
+}
+
+// DataSource defines the data source implementation.
+type DataSource struct {
+	client *global_settings.ClientWithResponses
+}
+
+// dataAttributes returns the computed attributes describing a context and its
+// fallback chain, shared by the context data sources.
+func dataAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"fallback_id": schema.StringAttribute{
+			MarkdownDescription: "The fallback of the context.",
+			Computed:            true,
+		},
+		"initial": schema.BoolAttribute{
+			MarkdownDescription: "Whether this is the initial context of the organization.",
+			Computed:            true,
+		},
+		"fallback_chain": schema.ListNestedAttribute{
+			MarkdownDescription: "The contexts used to fill gaps in the translations of this context, resolved " +
+				"recursively from `fallback_id`. The first element is the direct fallback, the last element " +
+				"is the context without a fallback.",
+			Computed: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						MarkdownDescription: "Context identifier",
+						Computed:            true,
+					},
+					"name": schema.StringAttribute{
+						MarkdownDescription: "The name of the context.",
+						Computed:            true,
+					},
+					"locale": schema.StringAttribute{
+						MarkdownDescription: "The locale of the context.",
+						Computed:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_context"
+}
+
+func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := dataAttributes()
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Context identifier. Exactly one of `id`, `locale` or `name` must be set.",
+			Optional:            true,
+			Computed:            true,
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRoot("locale"), path.MatchRoot("name")),
+			},
+		},
+		"locale": schema.StringAttribute{
+			MarkdownDescription: "The locale of the context.",
+			Optional:            true,
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "The name of the context.",
+			Optional:            true,
+			Computed:            true,
+		},
+	})
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing context by id, locale or name, including its fallback chain. " +
+			"Lookups by locale or name only match active contexts.",
+		Attributes: attributes,
+	}
+}
+
+func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, diag := utils.GetProviderData(req.ProviderData)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	d.client = data.GlobalSettingsClient
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ContextData
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, diag := FindContextData(ctx, d.client, data.ID.ValueString(), data.Locale.ValueString(), data.Name.ValueString())
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
+}
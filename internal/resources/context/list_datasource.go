@@ -0,0 +1,81 @@
+package context
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/labd/bluestonepim-go-sdk/global_settings"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ListDataSource{}
+
+func NewListDataSource() datasource.DataSource {
+	return &ListDataSource{}
+}
+
+// ListDataSource defines the data source implementation listing all contexts.
+type ListDataSource struct {
+	client *global_settings.ClientWithResponses
+}
+
+func (d *ListDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_contexts"
+}
+
+func (d *ListDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := dataAttributes()
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "Context identifier",
+		Computed:            true,
+	}
+	attributes["name"] = schema.StringAttribute{
+		MarkdownDescription: "The name of the context.",
+		Computed:            true,
+	}
+	attributes["locale"] = schema.StringAttribute{
+		MarkdownDescription: "The locale of the context.",
+		Computed:            true,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists all active contexts, including their fallback chains.",
+		Attributes: map[string]schema.Attribute{
+			"contexts": schema.ListNestedAttribute{
+				MarkdownDescription: "The contexts.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: attributes,
+				},
+			},
+		},
+	}
+}
+
+func (d *ListDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, diag := utils.GetProviderData(req.ProviderData)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	d.client = data.GlobalSettingsClient
+}
+
+func (d *ListDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	contexts, diag := ListContextData(ctx, d.client)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &ContextsData{Contexts: contexts})...)
+}
@@ -1,10 +1,52 @@
 package context
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
 
 type Context struct {
-	ID         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	Locale     types.String `tfsdk:"locale"`
-	FallbackID types.String `tfsdk:"fallback_id"`
+	ID            types.String      `tfsdk:"id"`
+	Name          types.String      `tfsdk:"name"`
+	Locale        utils.LocaleValue `tfsdk:"locale"`
+	FallbackID    types.String      `tfsdk:"fallback_id"`
+	AdoptArchived types.Bool        `tfsdk:"adopt_archived"`
+
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// keepLocalSettings copies the settings that are not stored in Bluestone PIM
+// from source.
+func (c *Context) keepLocalSettings(source *Context) {
+	c.DeletionProtection = source.DeletionProtection
+	c.Timeouts = source.Timeouts
+	if !source.AdoptArchived.IsNull() {
+		c.AdoptArchived = source.AdoptArchived
+	}
+}
+
+// ContextData describes the data source data model.
+type ContextData struct {
+	ID            types.String        `tfsdk:"id"`
+	Name          types.String        `tfsdk:"name"`
+	Locale        types.String        `tfsdk:"locale"`
+	FallbackID    types.String        `tfsdk:"fallback_id"`
+	Initial       types.Bool          `tfsdk:"initial"`
+	FallbackChain []FallbackChainLink `tfsdk:"fallback_chain"`
+}
+
+// FallbackChainLink is a context that fills gaps in the translations of
+// another context.
+type FallbackChainLink struct {
+	ID     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Locale types.String `tfsdk:"locale"`
+}
+
+// ContextsData describes the list data source data model.
+type ContextsData struct {
+	Contexts []ContextData `tfsdk:"contexts"`
 }
@@ -2,9 +2,13 @@ package context
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/labd/bluestonepim-go-sdk/global_settings"
 	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
 )
@@ -14,6 +18,7 @@ var (
 	_ resource.Resource                = &Resource{}
 	_ resource.ResourceWithConfigure   = &Resource{}
 	_ resource.ResourceWithImportState = &Resource{}
+	_ resource.ResourceWithModifyPlan  = &Resource{}
 )
 
 func NewResource() resource.Resource {
@@ -21,7 +26,9 @@ func NewResource() resource.Resource {
 }
 
 type Resource struct {
-	client *global_settings.ClientWithResponses
+	client             *global_settings.ClientWithResponses
+	fallbacks          *utils.ContextFallbacks
+	deletionProtection bool
 }
 
 // Metadata returns the data source type name.
@@ -30,7 +37,7 @@ func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, res
 }
 
 // Schema defines the schema for the data source.
-func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *Resource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Bluestone PIM allows you to communicate effectively with a global audience by " +
 			"providing product information in multiple languages and tailoring it to different linguistic " +
@@ -50,13 +57,30 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 				Required:            true,
 			},
 			"locale": schema.StringAttribute{
-				MarkdownDescription: "The locale of the context.",
-				Required:            true,
+				MarkdownDescription: "The locale of the context as a BCP-47 language tag, for example `nl-NL`. " +
+					"A language with an optional script and region is accepted, whether Bluestone PIM supports " +
+					"the locale is checked when the context is created. The locale is sent as configured, but " +
+					"differences in casing and separator, such as `nl_NL`, are not reported as changes.",
+				Required:   true,
+				CustomType: utils.LocaleType{},
 			},
 			"fallback_id": schema.StringAttribute{
-				MarkdownDescription: "The fallback of the context.",
-				Optional:            true,
+				MarkdownDescription: "The fallback of the context. Fallbacks that form a cycle are reported " +
+					"during plan.",
+				Optional: true,
+			},
+			"adopt_archived": schema.BoolAttribute{
+				MarkdownDescription: "Whether to adopt an archived context with the same locale on create. " +
+					"By default creation fails when such a context exists. When `true`, the archived context " +
+					"is updated to the configured values and managed by this resource instead.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
 			},
+			"deletion_protection": utils.DeletionProtectionAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": utils.TimeoutsBlock(ctx),
 		},
 	}
 }
@@ -74,6 +98,50 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, r
 	}
 
 	r.client = data.GlobalSettingsClient
+	r.deletionProtection = data.DeletionProtection
+	r.fallbacks = data.ContextFallbacks
+}
+
+// ModifyPlan reports a fallback cycle when the planned fallback of the context,
+// combined with the current fallbacks and the fallbacks planned for the other
+// contexts, loops back to a context.
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing can fall back to a context that is created or deleted
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state Context
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.FallbackID.IsUnknown() || plan.FallbackID.Equal(state.FallbackID) {
+		return
+	}
+
+	contexts, diag := ListContexts(ctx, r.client)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	current := make(map[string]string, len(contexts))
+	for _, c := range contexts {
+		if c.Fallback != nil {
+			current[c.Id] = *c.Fallback
+		}
+	}
+
+	fallbacks := r.fallbacks.Plan(current, state.ID.ValueString(), plan.FallbackID.ValueString())
+	if cycle := utils.FindFallbackCycle(fallbacks, state.ID.ValueString()); cycle != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("fallback_id"),
+			"Context fallback cycle",
+			fmt.Sprintf("The fallback of context %s forms a cycle: %s", state.ID.ValueString(), strings.Join(cycle, " -> ")),
+		)
+	}
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -85,11 +153,20 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
-	result, diag := CreateContext(ctx, r.client, &plan)
+	timeout, diags := plan.Timeouts.Create(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, diag := CreateContext(ctx, r.client, &plan, utils.SaveCreatedID(ctx, &resp.State, plan, path.Root("id")))
 	resp.Diagnostics.Append(diag)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	result.keepLocalSettings(&plan)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, result)
@@ -113,6 +190,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	result.keepLocalSettings(&current)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, result)
@@ -132,6 +210,14 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	timeout, diags := plan.Timeouts.Update(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	// Retrieve values from state
 	var state Context
 	diags = req.State.Get(ctx, &state)
@@ -145,6 +231,7 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	result.keepLocalSettings(&plan)
 
 	diags = resp.State.Set(ctx, result)
 	resp.Diagnostics.Append(diags...)
@@ -163,6 +250,19 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
+	timeout, diags := state.Timeouts.Delete(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if d := utils.CheckDeletionProtection(state.DeletionProtection, r.deletionProtection, "context "+state.ID.ValueString()); d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+
 	diag := DeleteContextByID(ctx, r.client, state.ID.ValueString())
 	resp.Diagnostics.Append(diag)
 	if resp.Diagnostics.HasError() {
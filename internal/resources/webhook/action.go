@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/labd/bluestonepim-go-sdk/notification_external"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &TestDeliveryAction{}
+	_ action.ActionWithConfigure = &TestDeliveryAction{}
+)
+
+func NewTestDeliveryAction() action.Action {
+	return &TestDeliveryAction{}
+}
+
+// TestDeliveryAction lets Bluestone PIM post a signed test message to a
+// webhook, to confirm the receiver accepts it before real events flow.
+type TestDeliveryAction struct {
+	client *notification_external.ClientWithResponses
+}
+
+type TestDelivery struct {
+	WebhookID types.String `tfsdk:"webhook_id"`
+}
+
+func (a *TestDeliveryAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook_test_delivery"
+}
+
+func (a *TestDeliveryAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sends a test message to a webhook. The message is signed with the secret of the " +
+			"webhook and posted to its URL, the same way real events are delivered. The action fails when " +
+			"Bluestone PIM does not send the message. Whether the receiver accepted it is not reported, run " +
+			"`terraform-provider-bluestonepim webhook-listen --secret <secret>` to receive and verify " +
+			"messages locally.",
+		Attributes: map[string]schema.Attribute{
+			"webhook_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the webhook to send the test message to.",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (a *TestDeliveryAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, diag := utils.GetProviderData(req.ProviderData)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	a.client = data.NotificationClient
+}
+
+func (a *TestDeliveryAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config TestDelivery
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The secret is not part of the webhook state when it is write-only, so
+	// the webhook is fetched to sign the message with its current secret.
+	res, err := a.client.GetWithResponse(ctx, config.WebhookID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed fetching webhook", err.Error())
+		return
+	}
+	if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Sending test message to %s", res.JSON200.Url),
+	})
+
+	call, d := PingWebhook(ctx, a.client, res.JSON200.Url, res.JSON200.Secret)
+	if d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+
+	tflog.Debug(ctx, "Sent test message to webhook", map[string]any{
+		"url":     call.Request.Url,
+		"method":  call.Request.HttpMethod,
+		"payload": call.Request.Payload,
+	})
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Test message sent to %s at %s", call.Request.Url,
+			time.UnixMilli(call.Timestamp).UTC().Format(time.RFC3339)),
+	})
+}
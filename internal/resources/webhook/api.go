@@ -9,10 +9,18 @@ import (
 	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
 	"net/http"
 	"slices"
+	"time"
 )
 
 const ResourceIdHeader = "Resource-Id"
 
+// recentDeliveriesPageSize is the number of most recent deliveries used to
+// determine the delivery health of a webhook.
+const recentDeliveriesPageSize = 100
+
+// searchPageSize is the page size used when listing webhooks.
+const searchPageSize = 100
+
 func GetWebhookByID(
 	ctx context.Context,
 	client *notification_external.ClientWithResponses,
@@ -26,35 +34,194 @@ func GetWebhookByID(
 		return nil, d
 	}
 
-	subscriptionRes, err := client.FindWebhookWithResponse(ctx, id)
-	if err != nil {
-		return nil, diag.NewErrorDiagnostic("Failed fetching webhook subscriptions", err.Error())
-	}
-	if d := utils.AssertStatusCode(subscriptionRes, http.StatusOK); d != nil {
+	eventTypes, d := GetSubscriptionsByID(ctx, client, id)
+	if d != nil {
 		return nil, d
 	}
 
-	eventTypes, diags := types.ListValueFrom(ctx, types.StringType, subscriptionRes.JSON200.EventTypes)
-	if diags.HasError() {
-		//Return the first error, but there might be more
-		return nil, diags.Errors()[0]
-	}
-
 	webhook := &Webhook{
 		ID:         types.StringValue(webhookRes.JSON200.Id),
 		Secret:     types.StringValue(webhookRes.JSON200.Secret),
 		URL:        types.StringValue(webhookRes.JSON200.Url),
 		Active:     types.BoolValue(webhookRes.JSON200.Active),
 		EventTypes: eventTypes,
+		// Not returned by the API, the resource keeps the configured value
+		ExclusiveEventTypes: types.BoolValue(true),
+		Reactivate:          types.BoolValue(true),
 	}
 
 	return webhook, nil
 }
 
+// GetSubscriptionsByID returns the event types the webhook is subscribed to.
+// A webhook without subscriptions is represented as null, matching an omitted
+// event_types attribute.
+func GetSubscriptionsByID(
+	ctx context.Context,
+	client *notification_external.ClientWithResponses,
+	id string,
+) (types.Set, diag.Diagnostic) {
+	subscriptionRes, err := client.FindWebhookWithResponse(ctx, id)
+	if err != nil {
+		return types.SetNull(types.StringType), diag.NewErrorDiagnostic("Failed fetching webhook subscriptions", err.Error())
+	}
+	if d := utils.AssertStatusCode(subscriptionRes, http.StatusOK); d != nil {
+		return types.SetNull(types.StringType), d
+	}
+
+	if len(subscriptionRes.JSON200.EventTypes) == 0 {
+		return types.SetNull(types.StringType), nil
+	}
+
+	eventTypes, diags := types.SetValueFrom(ctx, types.StringType, subscriptionRes.JSON200.EventTypes)
+	if diags.HasError() {
+		//Return the first error, but there might be more
+		return types.SetNull(types.StringType), diags.Errors()[0]
+	}
+	return eventTypes, nil
+}
+
+// GetWebhookDataByID returns the webhook including its delivery health.
+func GetWebhookDataByID(
+	ctx context.Context,
+	client *notification_external.ClientWithResponses,
+	id string,
+) (*WebhookData, diag.Diagnostic) {
+	webhookRes, err := client.GetWithResponse(ctx, id)
+	if err != nil {
+		return nil, diag.NewErrorDiagnostic("Failed fetching webhook", err.Error())
+	}
+	if d := utils.AssertStatusCode(webhookRes, http.StatusOK); d != nil {
+		return nil, d
+	}
+
+	return toWebhookData(ctx, client, webhookRes.JSON200)
+}
+
+// FindWebhookDataByURL returns the webhook posting to the given URL including
+// its delivery health.
+func FindWebhookDataByURL(
+	ctx context.Context,
+	client *notification_external.ClientWithResponses,
+	url string,
+) (*WebhookData, diag.Diagnostic) {
+	webhooks, d := ListWebhooks(ctx, client)
+	if d != nil {
+		return nil, d
+	}
+
+	var found []notification_external.WebhookResponse
+	for _, w := range webhooks {
+		if w.Url == url {
+			found = append(found, w)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, diag.NewErrorDiagnostic("Webhook not found", fmt.Sprintf("No webhook found with url %s", url))
+	case 1:
+		return toWebhookData(ctx, client, &found[0])
+	default:
+		return nil, diag.NewErrorDiagnostic(
+			"Multiple webhooks found",
+			fmt.Sprintf("Found %d webhooks with url %s, use the id to select one", len(found), url),
+		)
+	}
+}
+
+// ListWebhooks returns all webhooks.
+func ListWebhooks(
+	ctx context.Context,
+	client *notification_external.ClientWithResponses,
+) ([]notification_external.WebhookResponse, diag.Diagnostic) {
+	return utils.CollectPages(ctx, searchPageSize, func(ctx context.Context, page utils.Page) ([]notification_external.WebhookResponse, diag.Diagnostic) {
+		res, err := client.SearchWithResponse(ctx, notification_external.SearchJSONRequestBody{
+			Page:     int64(page.Number),
+			PageSize: int32(page.Size),
+		})
+		if err != nil {
+			return nil, diag.NewErrorDiagnostic("Failed listing webhooks", err.Error())
+		}
+		if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+			return nil, d
+		}
+		return res.JSON200.Data, nil
+	})
+}
+
+func toWebhookData(
+	ctx context.Context,
+	client *notification_external.ClientWithResponses,
+	webhook *notification_external.WebhookResponse,
+) (*WebhookData, diag.Diagnostic) {
+	eventTypes, d := GetSubscriptionsByID(ctx, client, webhook.Id)
+	if d != nil {
+		return nil, d
+	}
+
+	data := &WebhookData{
+		ID:                 types.StringValue(webhook.Id),
+		URL:                types.StringValue(webhook.Url),
+		Active:             types.BoolValue(webhook.Active),
+		EventTypes:         eventTypes,
+		RetryAttempts:      types.Int32Value(webhook.RetryAttempts),
+		LastDeliveryStatus: types.StringNull(),
+		LastDeliveryAt:     types.StringNull(),
+		RecentFailureCount: types.Int64Value(0),
+	}
+
+	if d := setDeliveryHealth(ctx, client, data); d != nil {
+		return nil, d
+	}
+	return data, nil
+}
+
+// setDeliveryHealth sets the delivery health fields of data based on the most
+// recent messages posted to the webhook.
+func setDeliveryHealth(
+	ctx context.Context,
+	client *notification_external.ClientWithResponses,
+	data *WebhookData,
+) diag.Diagnostic {
+	res, err := client.GetMessagesWithResponse(ctx, notification_external.GetMessagesJSONRequestBody{
+		Page:      0,
+		PageSize:  recentDeliveriesPageSize,
+		WebhookId: data.ID.ValueString(),
+	})
+	if err != nil {
+		return diag.NewErrorDiagnostic("Failed fetching webhook messages", err.Error())
+	}
+	if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+		return d
+	}
+
+	var last *notification_external.WebhookMessageResponse
+	var failures int64
+	for i, message := range *res.JSON200 {
+		if message.Status == notification_external.WebhookMessageResponseStatusERROR {
+			failures++
+		}
+		if last == nil || message.CreatedAt > last.CreatedAt {
+			last = &(*res.JSON200)[i]
+		}
+	}
+
+	data.RecentFailureCount = types.Int64Value(failures)
+	if last != nil {
+		data.LastDeliveryStatus = types.StringValue(string(last.Status))
+		data.LastDeliveryAt = types.StringValue(time.UnixMilli(last.CreatedAt).UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// CreateWebhook creates the webhook and then subscribes it to the event types.
+// onCreated is called with the id before subscribing.
 func CreateWebhook(
 	ctx context.Context,
 	client *notification_external.ClientWithResponses,
 	current *Webhook,
+	onCreated utils.OnCreated,
 ) (*Webhook, diag.Diagnostic) {
 	webhookRes, err := client.Create(ctx, notification_external.CreateJSONRequestBody{
 		Secret: current.Secret.ValueString(),
@@ -76,18 +243,16 @@ func CreateWebhook(
 			fmt.Sprintf("missing resource id. Expected header '%s' to be set", ResourceIdHeader),
 		)
 	}
-
-	var eventTypes []notification_external.WebhookEventTypeListRequestEventTypes
-	diags := current.EventTypes.ElementsAs(ctx, &eventTypes, false)
-	if diags.HasError() {
-		return nil, diags.Errors()[0]
+	if d := onCreated(id); d != nil {
+		return nil, d
 	}
 
-	subscriptionRes, err := client.SubscribeWithResponse(ctx, id, notification_external.SubscribeJSONRequestBody{EventTypes: eventTypes})
-	if err != nil {
-		return nil, diag.NewErrorDiagnostic("Failed adding subscriptions to webhook", err.Error())
+	// New webhooks are not always found right away
+	if d := waitForWebhook(ctx, client, id); d != nil {
+		return nil, d
 	}
-	if d := utils.AssertStatusCode(subscriptionRes, http.StatusOK); d != nil {
+
+	if d := UpdateSubscriptions(ctx, client, id, types.SetNull(types.StringType), current.EventTypes); d != nil {
 		return nil, d
 	}
 
@@ -115,54 +280,8 @@ func UpdateWebhookById(
 		}
 	}
 
-	if !current.EventTypes.Equal(planned.EventTypes) {
-		var currentEventTypes []notification_external.WebhookEventTypeListRequestEventTypes
-		diags := current.EventTypes.ElementsAs(ctx, &currentEventTypes, false)
-		if diags.HasError() {
-			return nil, diags.Errors()[0]
-		}
-
-		var plannedEventTypes []notification_external.WebhookEventTypeListRequestEventTypes
-		diags = planned.EventTypes.ElementsAs(ctx, &plannedEventTypes, false)
-		if diags.HasError() {
-			return nil, diags.Errors()[0]
-		}
-
-		var unsubscribeEventTypes []notification_external.WebhookEventTypeListRequestEventTypes
-		for _, currentEventType := range currentEventTypes {
-			if slices.Contains(plannedEventTypes, currentEventType) {
-				continue
-			}
-			unsubscribeEventTypes = append(unsubscribeEventTypes, currentEventType)
-		}
-		if len(unsubscribeEventTypes) > 0 {
-			res, err := client.UnsubscribeWithResponse(ctx, id, notification_external.UnsubscribeJSONRequestBody{EventTypes: unsubscribeEventTypes})
-			if err != nil {
-				return nil, diag.NewErrorDiagnostic("Failed removing subscriptions from webhook", err.Error())
-			}
-			if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
-				return nil, d
-			}
-		}
-
-		var subscribeEventTypes []notification_external.WebhookEventTypeListRequestEventTypes
-		for _, plannedEventType := range plannedEventTypes {
-			if slices.Contains(currentEventTypes, plannedEventType) {
-				continue
-			}
-			subscribeEventTypes = append(subscribeEventTypes, plannedEventType)
-		}
-
-		if len(subscribeEventTypes) > 0 {
-			res, err := client.SubscribeWithResponse(ctx, id, notification_external.SubscribeJSONRequestBody{EventTypes: subscribeEventTypes})
-			if err != nil {
-				return nil, diag.NewErrorDiagnostic("Failed adding subscriptions to webhook", err.Error())
-			}
-			if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
-				return nil, d
-			}
-		}
-
+	if d := UpdateSubscriptions(ctx, client, id, current.EventTypes, planned.EventTypes); d != nil {
+		return nil, d
 	}
 
 	return GetWebhookByID(ctx, client, id)
@@ -183,3 +302,103 @@ func DeleteWebhookByID(
 
 	return nil
 }
+
+// UpdateSubscriptions subscribes the webhook to the event types that are in
+// planned but not in current, and unsubscribes it from the event types that
+// are in current but not in planned. Event types in neither set are left
+// untouched.
+func UpdateSubscriptions(
+	ctx context.Context,
+	client *notification_external.ClientWithResponses,
+	id string,
+	current types.Set,
+	planned types.Set,
+) diag.Diagnostic {
+	if current.Equal(planned) {
+		return nil
+	}
+
+	var currentEventTypes []notification_external.WebhookEventTypeListRequestEventTypes
+	diags := current.ElementsAs(ctx, &currentEventTypes, false)
+	if diags.HasError() {
+		return diags.Errors()[0]
+	}
+
+	var plannedEventTypes []notification_external.WebhookEventTypeListRequestEventTypes
+	diags = planned.ElementsAs(ctx, &plannedEventTypes, false)
+	if diags.HasError() {
+		return diags.Errors()[0]
+	}
+
+	var unsubscribeEventTypes []notification_external.WebhookEventTypeListRequestEventTypes
+	for _, currentEventType := range currentEventTypes {
+		if slices.Contains(plannedEventTypes, currentEventType) {
+			continue
+		}
+		unsubscribeEventTypes = append(unsubscribeEventTypes, currentEventType)
+	}
+	if len(unsubscribeEventTypes) > 0 {
+		res, err := client.UnsubscribeWithResponse(ctx, id, notification_external.UnsubscribeJSONRequestBody{EventTypes: unsubscribeEventTypes})
+		if err != nil {
+			return diag.NewErrorDiagnostic("Failed removing subscriptions from webhook", err.Error())
+		}
+		if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+			return d
+		}
+	}
+
+	var subscribeEventTypes []notification_external.WebhookEventTypeListRequestEventTypes
+	for _, plannedEventType := range plannedEventTypes {
+		if slices.Contains(currentEventTypes, plannedEventType) {
+			continue
+		}
+		subscribeEventTypes = append(subscribeEventTypes, plannedEventType)
+	}
+	if len(subscribeEventTypes) > 0 {
+		res, err := client.SubscribeWithResponse(ctx, id, notification_external.SubscribeJSONRequestBody{EventTypes: subscribeEventTypes})
+		if err != nil {
+			return diag.NewErrorDiagnostic("Failed adding subscriptions to webhook", err.Error())
+		}
+		if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+			return d
+		}
+	}
+
+	return nil
+}
+
+// PingWebhook lets Bluestone PIM post a signed test message to the given URL,
+// and returns the call that was made.
+func PingWebhook(
+	ctx context.Context,
+	client *notification_external.ClientWithResponses,
+	url string,
+	secret string,
+) (*notification_external.WebhookCall, diag.Diagnostic) {
+	res, err := client.PingWebhookWithResponse(ctx, &notification_external.PingWebhookParams{
+		Url:    url,
+		Secret: secret,
+	})
+	if err != nil {
+		return nil, diag.NewErrorDiagnostic("Failed sending test message to webhook", err.Error())
+	}
+	if d := utils.AssertStatusCode(res, http.StatusOK); d != nil {
+		return nil, d
+	}
+
+	return res.JSON200, nil
+}
+
+// waitForWebhook waits until the created webhook is found.
+func waitForWebhook(ctx context.Context, client *notification_external.ClientWithResponses, id string) diag.Diagnostic {
+	return utils.WaitFor(ctx, "webhook "+id+" to be created", func(ctx context.Context) (bool, diag.Diagnostic) {
+		res, err := client.GetWithResponse(ctx, id)
+		if err != nil {
+			return false, diag.NewErrorDiagnostic("Failed fetching webhook", err.Error())
+		}
+		if utils.IsNotFound(res) {
+			return false, nil
+		}
+		return true, utils.AssertStatusCode(res, http.StatusOK)
+	})
+}
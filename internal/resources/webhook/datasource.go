@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"context"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/bluestonepim-go-sdk/notification_external"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DataSource{}
+
+func NewDataSource() datasource.DataSource {
+	return &DataSource{}
+}
+
+// DataSource defines the data source implementation.
+type DataSource struct {
+	client *notification_external.ClientWithResponses
+}
+
+// dataAttributes returns the computed attributes describing a webhook and its
+// delivery health, shared by the webhook data sources.
+func dataAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"active": schema.BoolAttribute{
+			MarkdownDescription: "Messages will not be posted to webhook if inactive.",
+			Computed:            true,
+		},
+		"event_types": schema.SetAttribute{
+			MarkdownDescription: "Set of events the webhook is subscribed to.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"retry_attempts": schema.Int32Attribute{
+			MarkdownDescription: "The number of times Bluestone PIM retries a failed delivery.",
+			Computed:            true,
+		},
+		"last_delivery_status": schema.StringAttribute{
+			MarkdownDescription: "The status of the most recent message posted to the webhook, for example " +
+				"`SENT`, `ERROR` or `WEBHOOK_INACTIVE`. Null when no messages were posted.",
+			Computed: true,
+		},
+		"last_delivery_at": schema.StringAttribute{
+			MarkdownDescription: "The RFC 3339 timestamp of the most recent message posted to the webhook.",
+			Computed:            true,
+		},
+		"recent_failure_count": schema.Int64Attribute{
+			MarkdownDescription: "The number of failed deliveries among the most recent messages posted to the webhook.",
+			Computed:            true,
+		},
+	}
+}
+
+func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook"
+}
+
+func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := dataAttributes()
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Webhook identifier. Exactly one of `id` or `url` must be set.",
+			Optional:            true,
+			Computed:            true,
+			Validators: []validator.String{
+				stringvalidator.ExactlyOneOf(path.MatchRoot("url")),
+			},
+		},
+		"url": schema.StringAttribute{
+			MarkdownDescription: "The URL that receives the message.",
+			Optional:            true,
+			Computed:            true,
+		},
+	})
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an existing webhook by id or url, including its delivery health.",
+		Attributes:          attributes,
+	}
+}
+
+func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, diag := utils.GetProviderData(req.ProviderData)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	d.client = data.NotificationClient
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WebhookData
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var result *WebhookData
+	var diag diag.Diagnostic
+	if !data.ID.IsNull() {
+		result, diag = GetWebhookDataByID(ctx, d.client, data.ID.ValueString())
+	} else {
+		result, diag = FindWebhookDataByURL(ctx, d.client, data.URL.ValueString())
+	}
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, result)...)
+}
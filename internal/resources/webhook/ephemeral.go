@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const defaultSecretLength = 32
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ ephemeral.EphemeralResource = &SecretEphemeralResource{}
+
+func NewSecretEphemeralResource() ephemeral.EphemeralResource {
+	return &SecretEphemeralResource{}
+}
+
+// SecretEphemeralResource generates a random webhook secret that is never
+// stored in the Terraform state.
+type SecretEphemeralResource struct{}
+
+type Secret struct {
+	Length types.Int64  `tfsdk:"length"`
+	Value  types.String `tfsdk:"value"`
+}
+
+// Metadata returns the ephemeral resource type name.
+func (e *SecretEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook_secret"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (e *SecretEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Generates a random secret for a webhook. The value is only available during the " +
+			"current Terraform run and is never stored in the state. Pass it to the `secret_wo` attribute of " +
+			"`bluestonepim_webhook` and to the system receiving the webhook in the same apply, and bump " +
+			"`secret_wo_version` to rotate it.",
+		Attributes: map[string]schema.Attribute{
+			"length": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The number of random bytes in the secret. The value is hex "+
+					"encoded, so the secret is twice as long. Defaults to `%d`.", defaultSecretLength),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(16, 128),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The generated secret.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+// Open generates the secret.
+func (e *SecretEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data Secret
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Length.IsNull() {
+		data.Length = types.Int64Value(defaultSecretLength)
+	}
+
+	buf := make([]byte, data.Length.ValueInt64())
+	if _, err := rand.Read(buf); err != nil {
+		resp.Diagnostics.AddError("Failed generating webhook secret", err.Error())
+		return
+	}
+	data.Value = types.StringValue(hex.EncodeToString(buf))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"slices"
+
+	"github.com/labd/bluestonepim-go-sdk/notification_external"
+)
+
+// EventTypes lists the event types a webhook can subscribe to.
+var EventTypes = []notification_external.WebhookEventTypeListRequestEventTypes{
+	notification_external.WebhookEventTypeListRequestEventTypesCATEGORYCREATED,
+	notification_external.WebhookEventTypeListRequestEventTypesCATEGORYREMOVED,
+	notification_external.WebhookEventTypeListRequestEventTypesCATEGORYWATCHARCHIVESTATE,
+	notification_external.WebhookEventTypeListRequestEventTypesCATEGORYWATCHATTRIBUTE,
+	notification_external.WebhookEventTypeListRequestEventTypesCATEGORYWATCHMETADATADESCRIPTION,
+	notification_external.WebhookEventTypeListRequestEventTypesCATEGORYWATCHMETADATANAME,
+	notification_external.WebhookEventTypeListRequestEventTypesCATEGORYWATCHMETADATANUMBER,
+	notification_external.WebhookEventTypeListRequestEventTypesCATEGORYWATCHMOVE,
+	notification_external.WebhookEventTypeListRequestEventTypesCATEGORYWATCHORDER,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTCREATED,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTSYNCDONE,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTWATCHASSET,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTWATCHATTRIBUTE,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTWATCHATTRIBUTEASSOCIATION,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTWATCHATTRIBUTEDISASSOCIATION,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTWATCHATTRIBUTEUPDATEVALUE,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTWATCHBUNDLE,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTWATCHBUNDLEQUANTITY,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTWATCHCATEGORY,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTWATCHLABEL,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTWATCHMETADATADESCRIPTION,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTWATCHMETADATANAME,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTWATCHMETADATANUMBER,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTWATCHRELATION,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTWATCHSTATE,
+	notification_external.WebhookEventTypeListRequestEventTypesPRODUCTWATCHVARIANT,
+}
+
+// EventTypeValues returns the event types as sorted strings, for use in
+// validators and data sources.
+func EventTypeValues() []string {
+	values := make([]string, 0, len(EventTypes))
+	for _, eventType := range EventTypes {
+		values = append(values, string(eventType))
+	}
+	slices.Sort(values)
+	return values
+}
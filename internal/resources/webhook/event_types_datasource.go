@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EventTypesDataSource{}
+
+func NewEventTypesDataSource() datasource.DataSource {
+	return &EventTypesDataSource{}
+}
+
+// EventTypesDataSource lists the event types a webhook can subscribe to.
+type EventTypesDataSource struct{}
+
+type EventTypesData struct {
+	EventTypes types.List `tfsdk:"event_types"`
+}
+
+func (d *EventTypesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook_event_types"
+}
+
+func (d *EventTypesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the event types a webhook can subscribe to. See " +
+			"[the documentation](https://help.bluestonepim.com/webhook-event-types) for a description of each event.",
+		Attributes: map[string]schema.Attribute{
+			"event_types": schema.ListAttribute{
+				MarkdownDescription: "The valid event types, sorted alphabetically.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *EventTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	eventTypes, diags := types.ListValueFrom(ctx, types.StringType, EventTypeValues())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &EventTypesData{EventTypes: eventTypes})...)
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/labd/bluestonepim-go-sdk/notification_external"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ListDataSource{}
+
+func NewListDataSource() datasource.DataSource {
+	return &ListDataSource{}
+}
+
+// ListDataSource defines the data source implementation listing all webhooks.
+type ListDataSource struct {
+	client *notification_external.ClientWithResponses
+}
+
+func (d *ListDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhooks"
+}
+
+func (d *ListDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := dataAttributes()
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "Webhook identifier",
+		Computed:            true,
+	}
+	attributes["url"] = schema.StringAttribute{
+		MarkdownDescription: "The URL that receives the message.",
+		Computed:            true,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists all webhooks, including their delivery health.",
+		Attributes: map[string]schema.Attribute{
+			"webhooks": schema.ListNestedAttribute{
+				MarkdownDescription: "The webhooks.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: attributes,
+				},
+			},
+		},
+	}
+}
+
+func (d *ListDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, diag := utils.GetProviderData(req.ProviderData)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	d.client = data.NotificationClient
+}
+
+func (d *ListDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	webhooks, diag := ListWebhooks(ctx, d.client)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	result := WebhooksData{Webhooks: make([]WebhookData, 0, len(webhooks))}
+	for i := range webhooks {
+		data, diag := toWebhookData(ctx, d.client, &webhooks[i])
+		if diag != nil {
+			resp.Diagnostics.Append(diag)
+			return
+		}
+		result.Webhooks = append(result.Webhooks, *data)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &result)...)
+}
@@ -1,11 +1,77 @@
 package webhook
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
 
 type Webhook struct {
-	ID         types.String `tfsdk:"id"`
-	Secret     types.String `tfsdk:"secret"`
-	URL        types.String `tfsdk:"url"`
-	Active     types.Bool   `tfsdk:"active"`
-	EventTypes types.List   `tfsdk:"event_types"`
+	ID              types.String `tfsdk:"id"`
+	Secret          types.String `tfsdk:"secret"`
+	SecretWO        types.String `tfsdk:"secret_wo"`
+	SecretWOVersion types.Int64  `tfsdk:"secret_wo_version"`
+	URL             types.String `tfsdk:"url"`
+	Active          types.Bool   `tfsdk:"active"`
+	EventTypes      types.Set    `tfsdk:"event_types"`
+
+	ExclusiveEventTypes types.Bool     `tfsdk:"exclusive_event_types"`
+	Reactivate          types.Bool     `tfsdk:"reactivate"`
+	DeletionProtection  types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
+}
+
+// usesWriteOnlySecret reports whether the secret is managed through secret_wo
+// instead of the secret attribute.
+func (w *Webhook) usesWriteOnlySecret() bool {
+	return w.Secret.IsNull()
+}
+
+// keepLocalSettings copies the settings of source that are not stored in
+// Bluestone PIM onto w. The secret returned by the API is dropped when source
+// manages it through secret_wo, so it never ends up in the state. When source
+// does not exclusively manage the event types, only the event types it owns
+// are kept, so subscriptions managed elsewhere are not reported as drift. An
+// empty set of event types is kept as well, as the API does not distinguish
+// it from no event types.
+func (w *Webhook) keepLocalSettings(source *Webhook) {
+	w.SecretWO = types.StringNull()
+	w.SecretWOVersion = source.SecretWOVersion
+	w.DeletionProtection = source.DeletionProtection
+	w.Timeouts = source.Timeouts
+	if source.usesWriteOnlySecret() {
+		w.Secret = types.StringNull()
+	}
+
+	if !source.ExclusiveEventTypes.IsNull() {
+		w.ExclusiveEventTypes = source.ExclusiveEventTypes
+	}
+	if !source.Reactivate.IsNull() {
+		w.Reactivate = source.Reactivate
+	}
+	if !w.ExclusiveEventTypes.ValueBool() {
+		w.EventTypes = utils.SetIntersection(w.EventTypes, source.EventTypes)
+	}
+	if w.EventTypes.IsNull() && !source.EventTypes.IsUnknown() && !source.EventTypes.IsNull() &&
+		len(source.EventTypes.Elements()) == 0 {
+		w.EventTypes = source.EventTypes
+	}
+}
+
+// WebhookData describes the data source data model.
+type WebhookData struct {
+	ID                 types.String `tfsdk:"id"`
+	URL                types.String `tfsdk:"url"`
+	Active             types.Bool   `tfsdk:"active"`
+	EventTypes         types.Set    `tfsdk:"event_types"`
+	RetryAttempts      types.Int32  `tfsdk:"retry_attempts"`
+	LastDeliveryStatus types.String `tfsdk:"last_delivery_status"`
+	LastDeliveryAt     types.String `tfsdk:"last_delivery_at"`
+	RecentFailureCount types.Int64  `tfsdk:"recent_failure_count"`
+}
+
+// WebhooksData describes the list data source data model.
+type WebhooksData struct {
+	Webhooks []WebhookData `tfsdk:"webhooks"`
 }
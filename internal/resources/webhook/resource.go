@@ -2,9 +2,14 @@ package webhook
 
 import (
 	"context"
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/labd/bluestonepim-go-sdk/notification_external"
 
@@ -16,9 +21,11 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &Resource{}
-	_ resource.ResourceWithConfigure   = &Resource{}
-	_ resource.ResourceWithImportState = &Resource{}
+	_ resource.Resource                 = &Resource{}
+	_ resource.ResourceWithConfigure    = &Resource{}
+	_ resource.ResourceWithImportState  = &Resource{}
+	_ resource.ResourceWithUpgradeState = &Resource{}
+	_ resource.ResourceWithModifyPlan   = &Resource{}
 )
 
 func NewResource() resource.Resource {
@@ -26,7 +33,8 @@ func NewResource() resource.Resource {
 }
 
 type Resource struct {
-	client *notification_external.ClientWithResponses
+	client             *notification_external.ClientWithResponses
+	deletionProtection bool
 }
 
 // Metadata returns the data source type name.
@@ -35,8 +43,9 @@ func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, res
 }
 
 // Schema defines the schema for the data source.
-func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *Resource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		MarkdownDescription: "External systems can be notified about relevant events in Bluestone PIM via webhooks. " +
 			"Selected event notifications are posted to the given external URL. See " +
 			"[the documentation](https://help.bluestonepim.com/work-with-events) for more information.",
@@ -48,9 +57,33 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 			"secret": schema.StringAttribute{
 				MarkdownDescription: "A password made by a subscriber. It can be used to validate that the message is " +
 					"legitimate. All messages will be signed with a SHA256 hash based on the request payload and " +
-					"this secret. This signature will be included in the request header x-bs-signature.",
-				Required:  true,
+					"this secret. This signature will be included in the request header x-bs-signature. " +
+					"Exactly one of `secret` or `secret_wo` must be set.",
+				Optional:  true,
 				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("secret_wo")),
+				},
+			},
+			"secret_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only variant of `secret`. The value is sent to Bluestone PIM but never " +
+					"stored in the Terraform state, so it can come from an ephemeral resource such as " +
+					"`bluestonepim_webhook_secret`. It is only applied on create and whenever " +
+					"`secret_wo_version` changes.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("secret_wo_version")),
+				},
+			},
+			"secret_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of the write-only secret. Change this value to send a new " +
+					"`secret_wo` to Bluestone PIM.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("secret_wo")),
+				},
 			},
 			"url": schema.StringAttribute{
 				MarkdownDescription: "The URL that receives the message. This will always be an HTTP(s) POST.",
@@ -62,15 +95,39 @@ func (r *Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *res
 				Optional:            true,
 				Default:             booldefault.StaticBool(true),
 			},
-			"event_types": schema.ListAttribute{
-				MarkdownDescription: "List of events to listen for. See " +
-					"[the documentation](https://help.bluestonepim.com/webhook-event-types) for all available webhooks",
+			"exclusive_event_types": schema.BoolAttribute{
+				MarkdownDescription: "Whether `event_types` is the complete list of subscriptions of the webhook. " +
+					"When `true`, subscriptions that are not in `event_types` are removed. Set to `false` when " +
+					"subscriptions are also managed elsewhere, for example with `bluestonepim_webhook_subscription`, " +
+					"so only the event types listed here are managed.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"reactivate": schema.BoolAttribute{
+				MarkdownDescription: "Whether to re-activate the webhook when it was deactivated outside of " +
+					"Terraform, for example by Bluestone PIM after repeated delivery failures. A deactivated " +
+					"webhook is reported with a warning and as drift on `active`. When `true`, the next apply " +
+					"re-activates it, like any other drift. When `false`, the plan keeps it inactive until " +
+					"`reactivate` is set to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"event_types": schema.SetAttribute{
+				MarkdownDescription: "Set of events to listen for. See " +
+					"[the documentation](https://help.bluestonepim.com/webhook-event-types) for all available webhooks, " +
+					"or use the `bluestonepim_webhook_event_types` data source.",
 				ElementType: types.StringType,
 				Optional:    true,
-				Validators: []validator.List{
-					listvalidator.UniqueValues(),
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(EventTypeValues()...)),
 				},
 			},
+			"deletion_protection": utils.DeletionProtectionAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": utils.TimeoutsBlock(ctx),
 		},
 	}
 }
@@ -88,6 +145,27 @@ func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, r
 	}
 
 	r.client = data.NotificationClient
+	r.deletionProtection = data.DeletionProtection
+}
+
+// ModifyPlan keeps a deactivated webhook inactive when reactivate is false,
+// instead of re-activating it because active is true in the configuration.
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state Webhook
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Reactivate.IsUnknown() || plan.Reactivate.ValueBool() || state.Active.ValueBool() || !plan.Active.ValueBool() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("active"), state.Active)...)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -99,11 +177,29 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 		return
 	}
 
-	result, diag := CreateWebhook(ctx, r.client, &plan)
+	timeout, diags := plan.Timeouts.Create(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request := plan
+	if plan.usesWriteOnlySecret() {
+		request.Secret, diags = getWriteOnlySecret(ctx, req.Config)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	result, diag := CreateWebhook(ctx, r.client, &request, utils.SaveCreatedID(ctx, &resp.State, plan, path.Root("id")))
 	resp.Diagnostics.Append(diag)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	result.keepLocalSettings(&plan)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, result)
@@ -128,6 +224,25 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 		return
 	}
 
+	if current.Active.ValueBool() && !result.Active.ValueBool() {
+		if current.Reactivate.ValueBool() || current.Reactivate.IsNull() {
+			resp.Diagnostics.AddWarning(
+				"Webhook was deactivated",
+				fmt.Sprintf("Webhook %s was deactivated outside of Terraform, for example by Bluestone PIM "+
+					"after repeated delivery failures. It will be re-activated on the next apply. Use the "+
+					"bluestonepim_webhook data source to inspect its delivery health.", current.ID.ValueString()),
+			)
+		} else {
+			resp.Diagnostics.AddWarning(
+				"Webhook was deactivated",
+				fmt.Sprintf("Webhook %s was deactivated outside of Terraform, for example by Bluestone PIM "+
+					"after repeated delivery failures. It is not re-activated because reactivate is false.",
+					current.ID.ValueString()),
+			)
+		}
+	}
+	result.keepLocalSettings(&current)
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, result)
 	resp.Diagnostics.Append(diags...)
@@ -146,6 +261,14 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
+	timeout, diags := plan.Timeouts.Update(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	// Retrieve values from state
 	var state Webhook
 	diags = req.State.Get(ctx, &state)
@@ -154,11 +277,32 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 		return
 	}
 
-	result, diag := UpdateWebhookById(ctx, r.client, state.ID.ValueString(), &state, &plan)
+	// The write-only secret is only sent when its version changes, otherwise
+	// the secret is left untouched.
+	current, request := state, plan
+	if plan.usesWriteOnlySecret() {
+		current.Secret = types.StringNull()
+		if !plan.SecretWOVersion.Equal(state.SecretWOVersion) {
+			request.Secret, diags = getWriteOnlySecret(ctx, req.Config)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
+	// When switching to non-exclusive event types the state still holds the
+	// subscriptions managed elsewhere, these must not be removed.
+	if state.ExclusiveEventTypes.ValueBool() && !plan.ExclusiveEventTypes.ValueBool() {
+		current.EventTypes = utils.SetIntersection(state.EventTypes, plan.EventTypes)
+	}
+
+	result, diag := UpdateWebhookById(ctx, r.client, state.ID.ValueString(), &current, &request)
 	resp.Diagnostics.Append(diag)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	result.keepLocalSettings(&plan)
 
 	diags = resp.State.Set(ctx, result)
 	resp.Diagnostics.Append(diags...)
@@ -177,6 +321,19 @@ func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp
 		return
 	}
 
+	timeout, diags := state.Timeouts.Delete(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if d := utils.CheckDeletionProtection(state.DeletionProtection, r.deletionProtection, "webhook "+state.ID.ValueString()); d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+
 	diag := DeleteWebhookByID(ctx, r.client, state.ID.ValueString())
 	resp.Diagnostics.Append(diag)
 	if resp.Diagnostics.HasError() {
@@ -188,3 +345,11 @@ func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequ
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+// getWriteOnlySecret reads secret_wo from the configuration. Write-only values
+// are never part of the plan or state.
+func getWriteOnlySecret(ctx context.Context, config tfsdk.Config) (types.String, diag.Diagnostics) {
+	var secret types.String
+	diags := config.GetAttribute(ctx, path.Root("secret_wo"), &secret)
+	return secret, diags
+}
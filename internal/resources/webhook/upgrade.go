@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// WebhookV0 is the state model of schema version 0, where event_types was a
+// list.
+type WebhookV0 struct {
+	ID         types.String `tfsdk:"id"`
+	Secret     types.String `tfsdk:"secret"`
+	URL        types.String `tfsdk:"url"`
+	Active     types.Bool   `tfsdk:"active"`
+	EventTypes types.List   `tfsdk:"event_types"`
+}
+
+var schemaV0 = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+		},
+		"secret": schema.StringAttribute{
+			Required:  true,
+			Sensitive: true,
+		},
+		"url": schema.StringAttribute{
+			Required: true,
+		},
+		"active": schema.BoolAttribute{
+			Computed: true,
+			Optional: true,
+		},
+		"event_types": schema.ListAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+		},
+	},
+}
+
+// UpgradeState converts the event_types list of schema version 0 to a set.
+func (r *Resource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior WebhookV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				eventTypes := types.SetNull(types.StringType)
+				if !prior.EventTypes.IsNull() {
+					var diags diag.Diagnostics
+					eventTypes, diags = types.SetValue(types.StringType, prior.EventTypes.Elements())
+					resp.Diagnostics.Append(diags...)
+					if resp.Diagnostics.HasError() {
+						return
+					}
+				}
+
+				upgraded := Webhook{
+					ID:              prior.ID,
+					Secret:          prior.Secret,
+					SecretWO:        types.StringNull(),
+					SecretWOVersion: types.Int64Null(),
+					URL:             prior.URL,
+					Active:          prior.Active,
+					EventTypes:      eventTypes,
+
+					ExclusiveEventTypes: types.BoolValue(true),
+					Reactivate:          types.BoolValue(true),
+					Timeouts:            utils.NullTimeouts(),
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+			},
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package webhook_subscription
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/bluestonepim-go-sdk/notification_external"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/resources/webhook"
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// GetWebhookSubscription returns the subscriptions of the webhook that are
+// managed by current. Subscriptions of other event types are ignored. Nil is
+// returned when none of the managed subscriptions exist anymore.
+func GetWebhookSubscription(
+	ctx context.Context,
+	client *notification_external.ClientWithResponses,
+	current *WebhookSubscription,
+) (*WebhookSubscription, diag.Diagnostic) {
+	subscribed, d := webhook.GetSubscriptionsByID(ctx, client, current.WebhookID.ValueString())
+	if d != nil {
+		return nil, d
+	}
+
+	eventTypes := utils.SetIntersection(current.EventTypes, subscribed)
+	if eventTypes.IsNull() {
+		return nil, nil
+	}
+
+	return &WebhookSubscription{
+		WebhookID:  current.WebhookID,
+		EventTypes: eventTypes,
+	}, nil
+}
+
+func CreateWebhookSubscription(
+	ctx context.Context,
+	client *notification_external.ClientWithResponses,
+	planned *WebhookSubscription,
+) (*WebhookSubscription, diag.Diagnostic) {
+	d := webhook.UpdateSubscriptions(ctx, client, planned.WebhookID.ValueString(),
+		types.SetNull(types.StringType), planned.EventTypes)
+	if d != nil {
+		return nil, d
+	}
+
+	return GetWebhookSubscription(ctx, client, planned)
+}
+
+func UpdateWebhookSubscription(
+	ctx context.Context,
+	client *notification_external.ClientWithResponses,
+	current *WebhookSubscription,
+	planned *WebhookSubscription,
+) (*WebhookSubscription, diag.Diagnostic) {
+	d := webhook.UpdateSubscriptions(ctx, client, current.WebhookID.ValueString(),
+		current.EventTypes, planned.EventTypes)
+	if d != nil {
+		return nil, d
+	}
+
+	return GetWebhookSubscription(ctx, client, planned)
+}
+
+func DeleteWebhookSubscription(
+	ctx context.Context,
+	client *notification_external.ClientWithResponses,
+	current *WebhookSubscription,
+) diag.Diagnostic {
+	return webhook.UpdateSubscriptions(ctx, client, current.WebhookID.ValueString(),
+		current.EventTypes, types.SetNull(types.StringType))
+}
@@ -0,0 +1,21 @@
+package webhook_subscription
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type WebhookSubscription struct {
+	WebhookID  types.String `tfsdk:"webhook_id"`
+	EventTypes types.Set    `tfsdk:"event_types"`
+
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// keepLocalSettings copies the settings that are not stored in Bluestone PIM
+// from source.
+func (w *WebhookSubscription) keepLocalSettings(source *WebhookSubscription) {
+	w.DeletionProtection = source.DeletionProtection
+	w.Timeouts = source.Timeouts
+}
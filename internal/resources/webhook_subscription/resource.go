@@ -0,0 +1,265 @@
+package webhook_subscription
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/labd/bluestonepim-go-sdk/notification_external"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/resources/webhook"
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &Resource{}
+	_ resource.ResourceWithConfigure   = &Resource{}
+	_ resource.ResourceWithImportState = &Resource{}
+)
+
+func NewResource() resource.Resource {
+	return &Resource{}
+}
+
+type Resource struct {
+	client             *notification_external.ClientWithResponses
+	deletionProtection bool
+}
+
+// Metadata returns the data source type name.
+func (r *Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook_subscription"
+}
+
+// Schema defines the schema for the data source.
+func (r *Resource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Subscribes an existing webhook to a set of event types. Only the event types listed " +
+			"here are managed, so several workspaces can each own their own subscriptions on a shared webhook. " +
+			"Set `exclusive_event_types` to `false` on the `bluestonepim_webhook` resource managing the webhook, " +
+			"otherwise it removes these subscriptions again.",
+		Attributes: map[string]schema.Attribute{
+			"webhook_id": schema.StringAttribute{
+				MarkdownDescription: "Webhook identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"event_types": schema.SetAttribute{
+				MarkdownDescription: "Set of events to subscribe the webhook to. See " +
+					"[the documentation](https://help.bluestonepim.com/webhook-event-types) for all available webhooks, " +
+					"or use the `bluestonepim_webhook_event_types` data source.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(webhook.EventTypeValues()...)),
+				},
+			},
+			"deletion_protection": utils.DeletionProtectionAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": utils.TimeoutsBlock(ctx),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *Resource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, diag := utils.GetProviderData(req.ProviderData)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	r.client = data.NotificationClient
+	r.deletionProtection = data.DeletionProtection
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan WebhookSubscription
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, diag := CreateWebhookSubscription(ctx, r.client, &plan)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	if result == nil {
+		resp.Diagnostics.AddError(
+			"Failed subscribing webhook",
+			fmt.Sprintf("The subscriptions of webhook %s were not found after subscribing", plan.WebhookID.ValueString()),
+		)
+		return
+	}
+
+	result.keepLocalSettings(&plan)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var current WebhookSubscription
+	diags := req.State.Get(ctx, &current)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, diag := GetWebhookSubscription(ctx, r.client, &current)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	// All managed subscriptions were removed outside of Terraform
+	if result == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	result.keepLocalSettings(&current)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan WebhookSubscription
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Update(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Retrieve values from state
+	var state WebhookSubscription
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, diag := UpdateWebhookSubscription(ctx, r.client, &state, &plan)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	if result == nil {
+		resp.Diagnostics.AddError(
+			"Failed updating webhook subscriptions",
+			fmt.Sprintf("The subscriptions of webhook %s were not found after subscribing", plan.WebhookID.ValueString()),
+		)
+		return
+	}
+
+	result.keepLocalSettings(&plan)
+
+	diags = resp.State.Set(ctx, result)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state WebhookSubscription
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, utils.DefaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if d := utils.CheckDeletionProtection(state.DeletionProtection, r.deletionProtection, "the subscriptions of webhook "+state.WebhookID.ValueString()); d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+
+	diag := DeleteWebhookSubscription(ctx, r.client, &state)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+}
+
+// ImportState imports the subscriptions using an ID of the form
+// `<webhook_id>:<event_type>,<event_type>`.
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	webhookID, eventTypes, found := strings.Cut(req.ID, ":")
+	if !found || webhookID == "" || eventTypes == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <webhook_id>:<event_type>,<event_type>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	eventTypeSet, diags := types.SetValueFrom(ctx, types.StringType, strings.Split(eventTypes, ","))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("webhook_id"), webhookID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("event_types"), eventTypeSet)...)
+}
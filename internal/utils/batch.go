@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const (
+	// ReadBatchWait is how long reads are collected before they are fetched
+	// in bulk.
+	ReadBatchWait = 20 * time.Millisecond
+	// ReadBatchSize is the maximum number of objects fetched in bulk.
+	ReadBatchSize = 100
+)
+
+// BatchFetcher fetches the objects with the given ids in a single request,
+// keyed by id. Objects that do not exist are left out.
+type BatchFetcher[T any] func(ctx context.Context, ids []string) (map[string]T, diag.Diagnostic)
+
+// Batcher coalesces concurrent reads of single objects into batches, which
+// are fetched using a bulk endpoint. Terraform reads resources concurrently,
+// so a refresh of many resources of the same type is served by a few
+// requests instead of one request per resource.
+type Batcher[T any] struct {
+	mu      sync.Mutex
+	wait    time.Duration
+	size    int
+	fetch   BatchFetcher[T]
+	pending *batch[T]
+}
+
+type batch[T any] struct {
+	ctx     context.Context
+	ids     []string
+	done    chan struct{}
+	results map[string]T
+	diag    diag.Diagnostic
+}
+
+// NewBatcher returns a batcher that fetches a batch once wait has passed
+// since its first read, or once it contains size ids.
+func NewBatcher[T any](wait time.Duration, size int, fetch BatchFetcher[T]) *Batcher[T] {
+	return &Batcher[T]{wait: wait, size: size, fetch: fetch}
+}
+
+// Load returns the object with the given id, fetched together with the other
+// objects read within the same window. It returns false when the object was
+// not returned by the bulk endpoint.
+func (b *Batcher[T]) Load(ctx context.Context, id string) (*T, bool, diag.Diagnostic) {
+	b.mu.Lock()
+	current := b.pending
+	if current == nil {
+		// The batch is shared between reads, so it is not cancelled with
+		// the read that started it
+		current = &batch[T]{ctx: context.WithoutCancel(ctx), done: make(chan struct{})}
+		b.pending = current
+		time.AfterFunc(b.wait, func() { b.dispatch(current) })
+	}
+	if !slices.Contains(current.ids, id) {
+		current.ids = append(current.ids, id)
+	}
+	if len(current.ids) >= b.size {
+		b.pending = nil
+		go b.run(current)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-current.done:
+	case <-ctx.Done():
+		return nil, false, diag.NewErrorDiagnostic("Unable to read data", ctx.Err().Error())
+	}
+
+	if current.diag != nil {
+		return nil, false, current.diag
+	}
+	result, ok := current.results[id]
+	if !ok {
+		return nil, false, nil
+	}
+	return &result, true, nil
+}
+
+// dispatch fetches the batch when it is still pending, which is not the case
+// when it was fetched after reaching its maximum size.
+func (b *Batcher[T]) dispatch(current *batch[T]) {
+	b.mu.Lock()
+	if b.pending != current {
+		b.mu.Unlock()
+		return
+	}
+	b.pending = nil
+	b.mu.Unlock()
+
+	b.run(current)
+}
+
+func (b *Batcher[T]) run(current *batch[T]) {
+	defer close(current.done)
+	current.results, current.diag = b.fetch(current.ctx, current.ids)
+}
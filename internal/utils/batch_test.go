@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+type recordingFetcher struct {
+	mu      sync.Mutex
+	batches [][]string
+}
+
+func (f *recordingFetcher) fetch(_ context.Context, ids []string) (map[string]string, diag.Diagnostic) {
+	f.mu.Lock()
+	f.batches = append(f.batches, slices.Clone(ids))
+	f.mu.Unlock()
+
+	result := map[string]string{}
+	for _, id := range ids {
+		if id != "missing" {
+			result[id] = "value " + id
+		}
+	}
+	return result, nil
+}
+
+func loadConcurrently(b *Batcher[string], ids ...string) map[string]string {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := map[string]string{}
+	for _, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, found, _ := b.Load(context.Background(), id)
+			if found {
+				mu.Lock()
+				results[id] = *value
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func TestBatcherCoalescesConcurrentLoads(t *testing.T) {
+	fetcher := &recordingFetcher{}
+	b := NewBatcher(50*time.Millisecond, 100, fetcher.fetch)
+
+	results := loadConcurrently(b, "a", "b", "c", "a", "missing")
+
+	if len(fetcher.batches) != 1 {
+		t.Fatalf("expected a single batch, got %v", fetcher.batches)
+	}
+	if len(fetcher.batches[0]) != 4 {
+		t.Errorf("expected 4 distinct ids, got %v", fetcher.batches[0])
+	}
+	if results["a"] != "value a" || results["c"] != "value c" {
+		t.Errorf("unexpected results %v", results)
+	}
+	if _, ok := results["missing"]; ok {
+		t.Errorf("expected missing to not be found, got %v", results)
+	}
+}
+
+func TestBatcherFetchesFullBatchImmediately(t *testing.T) {
+	fetcher := &recordingFetcher{}
+	b := NewBatcher(time.Hour, 2, fetcher.fetch)
+
+	results := loadConcurrently(b, "a", "b", "c", "d")
+
+	if len(fetcher.batches) != 2 {
+		t.Errorf("expected 2 batches, got %v", fetcher.batches)
+	}
+	if len(results) != 4 {
+		t.Errorf("expected 4 results, got %v", results)
+	}
+}
+
+func TestBatcherReturnsFetchDiagnostic(t *testing.T) {
+	b := NewBatcher(time.Millisecond, 10, func(_ context.Context, _ []string) (map[string]string, diag.Diagnostic) {
+		return nil, diag.NewErrorDiagnostic("failed", "bulk read failed")
+	})
+
+	_, found, d := b.Load(context.Background(), "a")
+	if found || d == nil || d.Detail() != "bulk read failed" {
+		t.Errorf("expected the fetch diagnostic, got found=%v diag=%v", found, d)
+	}
+}
@@ -0,0 +1,194 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ReadCache caches the responses of read requests for a single run of the
+// provider, so resources reading the same objects, such as the attributes of
+// a category, share a single request. The provider shares one instance
+// between all resources.
+//
+// Responses are keyed by method, URL and request body. A mutation removes the
+// cached responses of the whole API it is sent to, as it can also change
+// related objects, such as the children of the parent of a created category
+// or the attributes of the categories a deleted attribute definition was
+// assigned to.
+type ReadCache struct {
+	mu          sync.Mutex
+	entries     map[string]*cacheEntry
+	generations map[string]int
+}
+
+type cacheEntry struct {
+	api      string
+	done     chan struct{}
+	response *cachedResponse
+}
+
+type cachedResponse struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+func NewReadCache() *ReadCache {
+	return &ReadCache{
+		entries:     map[string]*cacheEntry{},
+		generations: map[string]int{},
+	}
+}
+
+type freshReadsKey struct{}
+
+// WithFreshReads returns a context of which the read requests bypass the
+// cache. Their responses replace the cached ones, so later reads see the
+// same data.
+func WithFreshReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshReadsKey{}, true)
+}
+
+// Transport returns a round tripper that serves read requests from the cache
+// and sends all other requests to transport.
+func (c *ReadCache) Transport(transport http.RoundTripper) http.RoundTripper {
+	return &cacheTransport{cache: c, transport: transport}
+}
+
+// invalidateAPI removes the cached responses of the API.
+func (c *ReadCache) invalidateAPI(api string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generations[api]++
+	for key, entry := range c.entries {
+		if entry.api == api {
+			delete(c.entries, key)
+		}
+	}
+}
+
+type cacheTransport struct {
+	cache     *ReadCache
+	transport http.RoundTripper
+}
+
+func (t *cacheTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	api := requestAPI(request)
+	if !isReadRequest(request) {
+		response, err := t.transport.RoundTrip(request)
+		t.cache.invalidateAPI(api)
+		return response, err
+	}
+
+	var body []byte
+	if request.Body != nil {
+		var err error
+		body, err = io.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	key := request.Method + " " + request.URL.String() + "\n" + string(body)
+
+	c := t.cache
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok && request.Context().Value(freshReadsKey{}) == nil {
+		c.mu.Unlock()
+		select {
+		case <-entry.done:
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		}
+		if entry.response != nil {
+			return entry.response.toResponse(request), nil
+		}
+		// The request this one waited for failed, so it is sent again
+		return t.transport.RoundTrip(request)
+	}
+	entry := &cacheEntry{api: api, done: make(chan struct{})}
+	c.entries[key] = entry
+	generation := c.generations[api]
+	c.mu.Unlock()
+
+	response, err := t.transport.RoundTrip(request)
+	var cached *cachedResponse
+	if err == nil && response.StatusCode == http.StatusOK {
+		cached, err = newCachedResponse(response)
+		if err != nil {
+			response = nil
+		}
+	}
+
+	c.mu.Lock()
+	defer close(entry.done)
+	defer c.mu.Unlock()
+
+	// Responses that were in flight during a mutation of their API
+	// may be outdated, so they are only returned to this request.
+	if cached != nil && c.generations[api] == generation && c.entries[key] == entry {
+		entry.response = cached
+	} else if c.entries[key] == entry {
+		delete(c.entries, key)
+	}
+
+	if cached == nil {
+		return response, err
+	}
+	return cached.toResponse(request), nil
+}
+
+// isReadRequest returns whether the request does not change any data. Apart
+// from GET requests, the APIs use POST requests to list and count objects
+// with filters.
+func isReadRequest(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodGet:
+		return true
+	case http.MethodPost:
+		return strings.HasSuffix(request.URL.Path, "/list") || strings.HasSuffix(request.URL.Path, "/count")
+	default:
+		return false
+	}
+}
+
+// requestAPI returns the host and the first segment of the path, which is
+// the API, for example pim.
+func requestAPI(request *http.Request) string {
+	api, _, _ := strings.Cut(strings.Trim(request.URL.Path, "/"), "/")
+	return request.URL.Host + "/" + api
+}
+
+func newCachedResponse(response *http.Response) (*cachedResponse, error) {
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &cachedResponse{
+		status: response.StatusCode,
+		header: response.Header.Clone(),
+		body:   body,
+	}, nil
+}
+
+func (r *cachedResponse) toResponse(request *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.status, http.StatusText(r.status)),
+		StatusCode:    r.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.body)),
+		ContentLength: int64(len(r.body)),
+		Request:       request,
+	}
+}
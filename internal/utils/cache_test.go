@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type countingTransport struct {
+	requests []string
+}
+
+func (t *countingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, request.Method+" "+request.URL.Path)
+	status := http.StatusOK
+	if strings.HasSuffix(request.URL.Path, "/missing") {
+		status = http.StatusNotFound
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(request.URL.Path)),
+		Request:    request,
+	}, nil
+}
+
+func sendRequest(t *testing.T, transport http.RoundTripper, method string, path string, body string) string {
+	t.Helper()
+	request, _ := http.NewRequest(method, "https://api.example.com"+path, strings.NewReader(body))
+	response, err := transport.RoundTrip(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	result, _ := io.ReadAll(response.Body)
+	return string(result)
+}
+
+func TestReadCacheReusesReadResponses(t *testing.T) {
+	upstream := &countingTransport{}
+	transport := NewReadCache().Transport(upstream)
+
+	for range 2 {
+		if body := sendRequest(t, transport, http.MethodGet, "/pim/catalogs/nodes/1/attributes", ""); body != "/pim/catalogs/nodes/1/attributes" {
+			t.Errorf("unexpected body %q", body)
+		}
+	}
+	if len(upstream.requests) != 1 {
+		t.Errorf("expected 1 request, got %v", upstream.requests)
+	}
+}
+
+func TestReadCacheKeysListRequestsByBody(t *testing.T) {
+	upstream := &countingTransport{}
+	transport := NewReadCache().Transport(upstream)
+
+	sendRequest(t, transport, http.MethodPost, "/pim/definitions/list", `{"page":0}`)
+	sendRequest(t, transport, http.MethodPost, "/pim/definitions/list", `{"page":0}`)
+	sendRequest(t, transport, http.MethodPost, "/pim/definitions/list", `{"page":1}`)
+	if len(upstream.requests) != 2 {
+		t.Errorf("expected 2 requests, got %v", upstream.requests)
+	}
+}
+
+func TestReadCacheInvalidatesAPIOnMutation(t *testing.T) {
+	upstream := &countingTransport{}
+	transport := NewReadCache().Transport(upstream)
+
+	sendRequest(t, transport, http.MethodGet, "/pim/catalogs/nodes/1/attributes", "")
+	sendRequest(t, transport, http.MethodGet, "/global-settings/context", "")
+	sendRequest(t, transport, http.MethodDelete, "/pim/definitions/2", "")
+	sendRequest(t, transport, http.MethodGet, "/pim/catalogs/nodes/1/attributes", "")
+	sendRequest(t, transport, http.MethodGet, "/global-settings/context", "")
+
+	expected := []string{
+		"GET /pim/catalogs/nodes/1/attributes",
+		"GET /global-settings/context",
+		"DELETE /pim/definitions/2",
+		"GET /pim/catalogs/nodes/1/attributes",
+	}
+	if strings.Join(upstream.requests, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected %v, got %v", expected, upstream.requests)
+	}
+}
+
+func TestReadCacheDoesNotCacheErrors(t *testing.T) {
+	upstream := &countingTransport{}
+	transport := NewReadCache().Transport(upstream)
+
+	sendRequest(t, transport, http.MethodGet, "/pim/definitions/missing", "")
+	sendRequest(t, transport, http.MethodGet, "/pim/definitions/missing", "")
+	if len(upstream.requests) != 2 {
+		t.Errorf("expected 2 requests, got %v", upstream.requests)
+	}
+}
+
+func TestReadCacheFreshReadsReplaceCachedResponses(t *testing.T) {
+	upstream := &countingTransport{}
+	transport := NewReadCache().Transport(upstream)
+
+	sendRequest(t, transport, http.MethodGet, "/pim/definitions/2", "")
+	request, _ := http.NewRequestWithContext(WithFreshReads(context.Background()), http.MethodGet, "https://api.example.com/pim/definitions/2", nil)
+	response, err := transport.RoundTrip(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	sendRequest(t, transport, http.MethodGet, "/pim/definitions/2", "")
+
+	if len(upstream.requests) != 2 {
+		t.Errorf("expected 2 requests, got %v", upstream.requests)
+	}
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// OnCreated is called by creates that need follow-up calls to complete the
+// object, with the id of the created object before the follow-up calls.
+type OnCreated func(id string) diag.Diagnostic
+
+// SaveCreatedID returns an OnCreated that saves the planned values with the
+// id of the created object to the state. When a follow-up call fails,
+// Terraform keeps the object in the state and marks it as tainted, instead of
+// losing track of it. Planned values that are not known yet, such as
+// computed attributes, are saved as null, as the state may not contain
+// unknown values. A zero idPath saves the planned values only, for objects
+// identified by their planned values.
+func SaveCreatedID(ctx context.Context, state *tfsdk.State, plan any, idPath path.Path) OnCreated {
+	return func(id string) diag.Diagnostic {
+		diags := state.Set(ctx, plan)
+		if !diags.HasError() {
+			raw, err := tftypes.Transform(state.Raw, func(_ *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+				if !v.IsKnown() {
+					return tftypes.NewValue(v.Type(), nil), nil
+				}
+				return v, nil
+			})
+			if err != nil {
+				return diag.NewErrorDiagnostic("Unable to save the created object", err.Error())
+			}
+			state.Raw = raw
+		}
+		if !diags.HasError() && !idPath.Equal(path.Empty()) {
+			diags.Append(state.SetAttribute(ctx, idPath, id)...)
+		}
+		if diags.HasError() {
+			// Return the first error, but there might be more
+			return diags.Errors()[0]
+		}
+		return nil
+	}
+}
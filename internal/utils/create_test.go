@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type createdModel struct {
+	Id     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Number types.String `tfsdk:"number"`
+	Values types.List   `tfsdk:"values"`
+}
+
+var createdValueType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"value":    types.StringType,
+	"value_id": types.StringType,
+}}
+
+func newCreatedModel(id types.String, name string) createdModel {
+	return createdModel{
+		Id:     id,
+		Name:   types.StringValue(name),
+		Number: types.StringNull(),
+		Values: types.ListNull(createdValueType),
+	}
+}
+
+func emptyState() tfsdk.State {
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":     schema.StringAttribute{Computed: true},
+			"name":   schema.StringAttribute{Required: true},
+			"number": schema.StringAttribute{Optional: true, Computed: true},
+			"values": schema.ListNestedAttribute{
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value":    schema.StringAttribute{Required: true},
+						"value_id": schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+	return tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(context.Background()), nil),
+	}
+}
+
+func TestSaveCreatedIDSavesPlanWithID(t *testing.T) {
+	ctx := context.Background()
+	state := emptyState()
+	plan := newCreatedModel(types.StringUnknown(), "example")
+
+	if d := SaveCreatedID(ctx, &state, plan, path.Root("id"))("1"); d != nil {
+		t.Fatalf("unexpected diagnostic %v", d)
+	}
+
+	var saved createdModel
+	if diags := state.Get(ctx, &saved); diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+	if saved.Id.ValueString() != "1" || saved.Name.ValueString() != "example" {
+		t.Errorf("unexpected state %v", saved)
+	}
+}
+
+func TestSaveCreatedIDWithoutIDPath(t *testing.T) {
+	ctx := context.Background()
+	state := emptyState()
+	plan := newCreatedModel(types.StringValue("planned"), "example")
+
+	if d := SaveCreatedID(ctx, &state, plan, path.Empty())("1"); d != nil {
+		t.Fatalf("unexpected diagnostic %v", d)
+	}
+
+	var saved createdModel
+	state.Get(ctx, &saved)
+	if saved.Id.ValueString() != "planned" {
+		t.Errorf("expected the planned id, got %v", saved)
+	}
+}
+
+func TestSaveCreatedIDSavesUnknownValuesAsNull(t *testing.T) {
+	ctx := context.Background()
+	state := emptyState()
+	plan := newCreatedModel(types.StringUnknown(), "example")
+	plan.Number = types.StringUnknown()
+	plan.Values = types.ListValueMust(createdValueType, []attr.Value{
+		types.ObjectValueMust(createdValueType.AttrTypes, map[string]attr.Value{
+			"value":    types.StringValue("red"),
+			"value_id": types.StringUnknown(),
+		}),
+	})
+
+	if d := SaveCreatedID(ctx, &state, plan, path.Root("id"))("1"); d != nil {
+		t.Fatalf("unexpected diagnostic %v", d)
+	}
+	if !state.Raw.IsFullyKnown() {
+		t.Fatalf("expected no unknown values in the state, got %s", state.Raw)
+	}
+
+	var saved createdModel
+	if diags := state.Get(ctx, &saved); diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+	if saved.Id.ValueString() != "1" || !saved.Number.IsNull() {
+		t.Errorf("expected the id and a null number, got %v", saved)
+	}
+	value := saved.Values.Elements()[0].(types.Object).Attributes()
+	if value["value"].(types.String).ValueString() != "red" || !value["value_id"].IsNull() {
+		t.Errorf("expected the planned value and a null value id, got %v", value)
+	}
+}
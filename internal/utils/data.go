@@ -13,6 +13,16 @@ type ProviderData struct {
 	PimClient            *pim.ClientWithResponses
 	NotificationClient   *notification_external.ClientWithResponses
 	GlobalSettingsClient *global_settings.ClientWithResponses
+	ContextFallbacks     *ContextFallbacks
+
+	// CategoryReads and AttributeDefinitionReads coalesce the reads of the
+	// resources into bulk requests.
+	CategoryReads            *Batcher[pim.CategoryBasicResponse]
+	AttributeDefinitionReads *Batcher[pim.AttributeDefinitionResponse]
+
+	// DeletionProtection is the default of the deletion_protection
+	// attribute of resources.
+	DeletionProtection bool
 }
 
 func GetProviderData(data any) (*ProviderData, diag.Diagnostic) {
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DeletionProtectionAttribute returns the deletion_protection attribute that
+// all resources share.
+func DeletionProtectionAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: "Whether Terraform is prevented from deleting the resource. The check runs when " +
+			"the resource is deleted during apply, also when it is replaced, so with `create_before_destroy` " +
+			"the replacement is created before the deletion fails. Set to `false` and apply before " +
+			"destroying the resource. Defaults to the `deletion_protection` setting of the provider.",
+		Optional: true,
+	}
+}
+
+// CheckDeletionProtection returns an error when deletion of the resource is
+// protected, either by its deletion_protection attribute or, when that is not
+// set, by the provider default.
+func CheckDeletionProtection(value types.Bool, providerDefault bool, resource string) diag.Diagnostic {
+	protected := providerDefault
+	if !value.IsNull() && !value.IsUnknown() {
+		protected = value.ValueBool()
+	}
+	if !protected {
+		return nil
+	}
+
+	return diag.NewErrorDiagnostic(
+		"Deletion protection enabled",
+		fmt.Sprintf("Cannot delete %s because deletion protection is enabled. Set deletion_protection to "+
+			"false and apply the change before deleting it.", resource),
+	)
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCheckDeletionProtection(t *testing.T) {
+	tests := []struct {
+		name            string
+		value           types.Bool
+		providerDefault bool
+		protected       bool
+	}{
+		{"not set", types.BoolNull(), false, false},
+		{"provider default", types.BoolNull(), true, true},
+		{"enabled", types.BoolValue(true), false, true},
+		{"disabled overrides provider default", types.BoolValue(false), true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := CheckDeletionProtection(tt.value, tt.providerDefault, "category my-category")
+			if protected := d != nil; protected != tt.protected {
+				t.Errorf("expected protected to be %v, got %v", tt.protected, protected)
+			}
+		})
+	}
+}
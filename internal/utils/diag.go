@@ -2,7 +2,6 @@ package utils
 
 import (
 	"fmt"
-	"github.com/labd/bluestonepim-go-sdk/pim"
 	"reflect"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -18,25 +17,36 @@ func AssertStatusCode(response Response, statusCode int) diag.Diagnostic {
 	}
 
 	if response.StatusCode() >= 400 && response.StatusCode() < 500 {
-		e := getErrorResponse(response)
-		if e != nil {
+		if message := getErrorMessage(response); message != nil {
 			return diag.NewErrorDiagnostic(
-				fmt.Sprintf("HTTP %d error", response.StatusCode()), *e.Error)
+				fmt.Sprintf("HTTP %d error", response.StatusCode()), *message)
 		}
 	}
 
 	return diag.NewErrorDiagnostic("Unexpected status code", fmt.Sprintf("Expected %d, got %d", statusCode, response.StatusCode()))
 }
 
-func getErrorResponse(response Response) *pim.ErrorResponse {
+// getErrorMessage returns the error message of the ErrorResponse matching the
+// status code. Each SDK package generates its own ErrorResponse type, so the
+// message is looked up by field name.
+func getErrorMessage(response Response) *string {
 	val := reflect.ValueOf(response)
 	if val.Kind() == reflect.Ptr {
 		val = val.Elem()
 	}
 
 	field := val.FieldByName(fmt.Sprintf("JSON%d", response.StatusCode()))
-	if field.IsValid() && !field.IsNil() {
-		return field.Interface().(*pim.ErrorResponse)
+	if !field.IsValid() || field.Kind() != reflect.Ptr || field.IsNil() {
+		return nil
+	}
+
+	message := field.Elem().FieldByName("Error")
+	if !message.IsValid() || message.Kind() != reflect.Ptr || message.IsNil() {
+		return nil
+	}
+
+	if s, ok := message.Interface().(*string); ok {
+		return s
 	}
 	return nil
 }
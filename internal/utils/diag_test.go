@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labd/bluestonepim-go-sdk/notification_external"
+	"github.com/labd/bluestonepim-go-sdk/pim"
+)
+
+func TestAssertStatusCodeReturnsNilOnMatch(t *testing.T) {
+	response := &pim.GetNodeResponse{HTTPResponse: &http.Response{StatusCode: http.StatusOK}}
+	if d := AssertStatusCode(response, http.StatusOK); d != nil {
+		t.Errorf("expected no diagnostic, got %s", d.Detail())
+	}
+}
+
+func TestAssertStatusCodeUsesErrorMessage(t *testing.T) {
+	response := &pim.GetNodeResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusNotFound},
+		JSON404:      &pim.ErrorResponse{Error: Ref("Category not found")},
+	}
+	d := AssertStatusCode(response, http.StatusOK)
+	if d == nil || d.Detail() != "Category not found" {
+		t.Errorf("expected error message from response, got %v", d)
+	}
+}
+
+func TestAssertStatusCodeUsesErrorMessageFromOtherPackages(t *testing.T) {
+	response := &notification_external.GetResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusBadRequest},
+		JSON400:      &notification_external.ErrorResponse{Error: Ref("Invalid webhook")},
+	}
+	d := AssertStatusCode(response, http.StatusOK)
+	if d == nil || d.Detail() != "Invalid webhook" {
+		t.Errorf("expected error message from response, got %v", d)
+	}
+}
+
+func TestAssertStatusCodeWithoutErrorBody(t *testing.T) {
+	response := &pim.GetNodeResponse{HTTPResponse: &http.Response{StatusCode: http.StatusBadRequest}}
+	d := AssertStatusCode(response, http.StatusOK)
+	if d == nil || d.Summary() != "Unexpected status code" {
+		t.Errorf("expected unexpected status code diagnostic, got %v", d)
+	}
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"maps"
+	"sync"
+)
+
+// ContextFallbacks collects the context fallbacks planned by the context
+// resources during a single plan. The provider shares one instance between
+// all resources, so each context can check its fallback chain against the
+// changes planned for the other contexts.
+type ContextFallbacks struct {
+	mu      sync.Mutex
+	planned map[string]string
+}
+
+func NewContextFallbacks() *ContextFallbacks {
+	return &ContextFallbacks{planned: map[string]string{}}
+}
+
+// Plan records the planned fallback of the context and returns the current
+// fallbacks overlaid with all planned ones. An empty fallback means the
+// context has no fallback.
+func (f *ContextFallbacks) Plan(current map[string]string, id string, fallback string) map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.planned[id] = fallback
+
+	result := maps.Clone(current)
+	maps.Copy(result, f.planned)
+	return result
+}
+
+// FindFallbackCycle follows the fallbacks starting at id and returns the
+// contexts forming a cycle, starting and ending with the first repeated
+// context. It returns nil when the chain ends.
+func FindFallbackCycle(fallbacks map[string]string, id string) []string {
+	var chain []string
+	visited := map[string]int{}
+	for current := id; current != ""; current = fallbacks[current] {
+		if i, ok := visited[current]; ok {
+			return append(chain[i:], current)
+		}
+		visited[current] = len(chain)
+		chain = append(chain, current)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFindFallbackCycleReturnsNilWhenChainEnds(t *testing.T) {
+	fallbacks := map[string]string{"nl-BE": "nl-NL", "nl-NL": "en"}
+	if cycle := FindFallbackCycle(fallbacks, "nl-BE"); cycle != nil {
+		t.Errorf("expected no cycle, got %v", cycle)
+	}
+}
+
+func TestFindFallbackCycleFindsSelfReference(t *testing.T) {
+	cycle := FindFallbackCycle(map[string]string{"nl-NL": "nl-NL"}, "nl-NL")
+	if !slices.Equal(cycle, []string{"nl-NL", "nl-NL"}) {
+		t.Errorf("expected [nl-NL nl-NL], got %v", cycle)
+	}
+}
+
+func TestFindFallbackCycleUsesPlannedFallbacks(t *testing.T) {
+	fallbacks := NewContextFallbacks()
+	current := map[string]string{"a": "b", "b": "en", "c": "a"}
+
+	if cycle := FindFallbackCycle(fallbacks.Plan(current, "a", "b"), "a"); cycle != nil {
+		t.Errorf("expected no cycle, got %v", cycle)
+	}
+
+	cycle := FindFallbackCycle(fallbacks.Plan(current, "b", "c"), "b")
+	if !slices.Equal(cycle, []string{"b", "c", "a", "b"}) {
+		t.Errorf("expected [b c a b], got %v", cycle)
+	}
+}
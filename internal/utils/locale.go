@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"golang.org/x/text/language"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = LocaleType{}
+	_ basetypes.StringValuableWithSemanticEquals = LocaleValue{}
+	_ xattr.ValidateableAttribute                = LocaleValue{}
+)
+
+// ParseLocale parses a locale as a BCP-47 language tag, accepting underscores
+// as separator, and returns it in canonical form, for example nl_nl becomes
+// nl-NL. Only a language with an optional script and region is accepted,
+// without variants or extensions. Whether Bluestone PIM supports the locale
+// is only known when the context is created.
+func ParseLocale(locale string) (string, error) {
+	tag, err := language.Parse(strings.ReplaceAll(locale, "_", "-"))
+	if err != nil {
+		return "", fmt.Errorf("%q is not a valid BCP-47 locale: %w", locale, err)
+	}
+
+	base, confidence := tag.Base()
+	if confidence != language.Exact || base.String() == "und" {
+		return "", fmt.Errorf("%q does not start with a language", locale)
+	}
+	if len(tag.Variants()) > 0 || len(tag.Extensions()) > 0 {
+		return "", fmt.Errorf("%q is not accepted, only a language with an optional script and region is", locale)
+	}
+
+	return tag.String(), nil
+}
+
+// LocalesEqual reports whether a and b are the same locale, ignoring
+// differences in casing and separators.
+func LocalesEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ca, err := ParseLocale(a)
+	if err != nil {
+		return false
+	}
+	cb, err := ParseLocale(b)
+	if err != nil {
+		return false
+	}
+	return ca == cb
+}
+
+// LocaleType is a string type holding a BCP-47 locale.
+type LocaleType struct {
+	basetypes.StringType
+}
+
+func (t LocaleType) String() string {
+	return "LocaleType"
+}
+
+func (t LocaleType) ValueType(ctx context.Context) attr.Value {
+	return LocaleValue{}
+}
+
+func (t LocaleType) Equal(o attr.Type) bool {
+	other, ok := o.(LocaleType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t LocaleType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return LocaleValue{StringValue: in}, nil
+}
+
+func (t LocaleType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return LocaleValue{StringValue: stringValue}, nil
+}
+
+// LocaleValue is a BCP-47 locale. Values that only differ in casing or
+// separator, such as nl_NL and nl-NL, are semantically equal.
+type LocaleValue struct {
+	basetypes.StringValue
+}
+
+func NewLocaleValue(value string) LocaleValue {
+	return LocaleValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v LocaleValue) Type(_ context.Context) attr.Type {
+	return LocaleType{}
+}
+
+func (v LocaleValue) Equal(o attr.Value) bool {
+	other, ok := o.(LocaleValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v LocaleValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(LocaleValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return LocalesEqual(v.ValueString(), newValue.ValueString()), diags
+}
+
+func (v LocaleValue) ValidateAttribute(_ context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, err := ParseLocale(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid locale", err.Error())
+	}
+}
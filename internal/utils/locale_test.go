@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseLocaleCanonicalizes(t *testing.T) {
+	cases := map[string]string{
+		"nl_NL":      "nl-NL",
+		"nl-nl":      "nl-NL",
+		"en":         "en",
+		"zh_Hant":    "zh-Hant",
+		"sr-Latn-RS": "sr-Latn-RS",
+	}
+	for in, expected := range cases {
+		actual, err := ParseLocale(in)
+		if err != nil {
+			t.Errorf("ParseLocale(%q) returned error: %s", in, err)
+			continue
+		}
+		if actual != expected {
+			t.Errorf("ParseLocale(%q) = %q, expected %q", in, actual, expected)
+		}
+	}
+}
+
+func TestParseLocaleRejectsUnsupported(t *testing.T) {
+	for _, in := range []string{"", "dutch", "xx-NL", "und", "de-DE-1901", "en-US-u-ca-buddhist"} {
+		if _, err := ParseLocale(in); err == nil {
+			t.Errorf("ParseLocale(%q) expected an error", in)
+		}
+	}
+}
+
+func TestLocaleValueSemanticEquals(t *testing.T) {
+	equal, diags := NewLocaleValue("nl_NL").StringSemanticEquals(context.Background(), NewLocaleValue("nl-NL"))
+	if diags.HasError() || !equal {
+		t.Error("expected nl_NL and nl-NL to be semantically equal")
+	}
+
+	equal, diags = NewLocaleValue("nl-NL").StringSemanticEquals(context.Background(), NewLocaleValue("nl-BE"))
+	if diags.HasError() || equal {
+		t.Error("expected nl-NL and nl-BE not to be semantically equal")
+	}
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// DefaultPageSize is the page size used when listing resources.
+const DefaultPageSize = 1000
+
+// Page identifies a page of a list request. Pages are numbered from zero.
+type Page struct {
+	Number int
+	Size   int
+}
+
+// PageFetcher fetches a single page of a list request.
+type PageFetcher[T any] func(ctx context.Context, page Page) ([]T, diag.Diagnostic)
+
+// Paginate returns an iterator over the items of all pages returned by
+// fetch. Fetching stops at the first page with fewer items than the page
+// size, or more when the endpoint ignores the paging parameters. It also
+// stops when a page starts with the same item as the previous page, as an
+// endpoint that ignores the paging parameters returns the same full page
+// every time. A failing page is yielded as a diagnostic, after which
+// iteration stops.
+func Paginate[T any](ctx context.Context, size int, fetch PageFetcher[T]) iter.Seq2[T, diag.Diagnostic] {
+	return func(yield func(T, diag.Diagnostic) bool) {
+		var previous []T
+		for number := 0; ; number++ {
+			items, d := fetch(ctx, Page{Number: number, Size: size})
+			if d != nil {
+				var zero T
+				yield(zero, d)
+				return
+			}
+			if len(items) > 0 && len(previous) > 0 && reflect.DeepEqual(items[0], previous[0]) {
+				return
+			}
+			previous = items
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(items) != size {
+				return
+			}
+		}
+	}
+}
+
+// CollectPages returns the items of all pages returned by fetch.
+func CollectPages[T any](ctx context.Context, size int, fetch PageFetcher[T]) ([]T, diag.Diagnostic) {
+	var result []T
+	for item, d := range Paginate(ctx, size, fetch) {
+		if d != nil {
+			return nil, d
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// Items returns the items of an optional list in a response, as used by
+// the pim API.
+func Items[T any](data *[]T) []T {
+	if data == nil {
+		return nil
+	}
+	return *data
+}
+
+// PageQuery returns a request editor that adds the page and pageSize query
+// parameters, for list endpoints of which the SDK does not expose them.
+func PageQuery(page Page) func(ctx context.Context, req *http.Request) error {
+	return func(_ context.Context, req *http.Request) error {
+		query := req.URL.Query()
+		query.Set("page", strconv.Itoa(page.Number))
+		query.Set("pageSize", strconv.Itoa(page.Size))
+		req.URL.RawQuery = query.Encode()
+		return nil
+	}
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func fetchRange(total int, requested *[]Page) PageFetcher[int] {
+	return func(_ context.Context, page Page) ([]int, diag.Diagnostic) {
+		*requested = append(*requested, page)
+		var items []int
+		for i := page.Number * page.Size; i < total && i < (page.Number+1)*page.Size; i++ {
+			items = append(items, i)
+		}
+		return items, nil
+	}
+}
+
+func TestCollectPagesReadsAllPages(t *testing.T) {
+	var requested []Page
+	result, d := CollectPages(context.Background(), 2, fetchRange(5, &requested))
+	if d != nil {
+		t.Fatalf("unexpected diagnostic: %s", d.Detail())
+	}
+	if !slices.Equal(result, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("expected [0 1 2 3 4], got %v", result)
+	}
+	if len(requested) != 3 {
+		t.Errorf("expected 3 pages to be requested, got %d", len(requested))
+	}
+}
+
+func TestCollectPagesRequestsEmptyPageAfterFullPage(t *testing.T) {
+	var requested []Page
+	result, _ := CollectPages(context.Background(), 2, fetchRange(4, &requested))
+	if len(result) != 4 {
+		t.Errorf("expected 4 items, got %v", result)
+	}
+	if len(requested) != 3 {
+		t.Errorf("expected 3 pages to be requested, got %d", len(requested))
+	}
+}
+
+func TestCollectPagesStopsWhenPagingIsIgnored(t *testing.T) {
+	calls := 0
+	result, _ := CollectPages(context.Background(), 2, func(_ context.Context, _ Page) ([]int, diag.Diagnostic) {
+		calls++
+		return []int{1, 2, 3}, nil
+	})
+	if len(result) != 3 || calls != 1 {
+		t.Errorf("expected a single page of 3 items, got %v after %d calls", result, calls)
+	}
+}
+
+func TestCollectPagesStopsWhenFullPageIsRepeated(t *testing.T) {
+	calls := 0
+	result, _ := CollectPages(context.Background(), 2, func(_ context.Context, _ Page) ([]int, diag.Diagnostic) {
+		calls++
+		if calls > 3 {
+			t.Fatal("expected fetching to stop")
+		}
+		return []int{1, 2}, nil
+	})
+	if !slices.Equal(result, []int{1, 2}) || calls != 2 {
+		t.Errorf("expected a single page of 2 items, got %v after %d calls", result, calls)
+	}
+}
+
+func TestCollectPagesReturnsDiagnostic(t *testing.T) {
+	result, d := CollectPages(context.Background(), 2, func(_ context.Context, page Page) ([]int, diag.Diagnostic) {
+		if page.Number == 1 {
+			return nil, diag.NewErrorDiagnostic("failed", "page 1")
+		}
+		return []int{1, 2}, nil
+	})
+	if d == nil || d.Detail() != "page 1" {
+		t.Errorf("expected diagnostic for page 1, got %v", d)
+	}
+	if result != nil {
+		t.Errorf("expected no result, got %v", result)
+	}
+}
+
+func TestPaginateStopsWhenConsumerStops(t *testing.T) {
+	var requested []Page
+	for item := range Paginate(context.Background(), 2, fetchRange(10, &requested)) {
+		if item == 2 {
+			break
+		}
+	}
+	if len(requested) != 2 {
+		t.Errorf("expected 2 pages to be requested, got %d", len(requested))
+	}
+}
+
+func TestPageQuerySetsParameters(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/contexts?contextState=ACTIVE", nil)
+	if err := PageQuery(Page{Number: 2, Size: 50})(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	query := req.URL.Query()
+	if query.Get("page") != "2" || query.Get("pageSize") != "50" || query.Get("contextState") != "ACTIVE" {
+		t.Errorf("unexpected query %s", req.URL.RawQuery)
+	}
+}
@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// SetIntersection returns the elements of a that are also in b. An empty
+// result is returned as a null set.
+func SetIntersection(a, b types.Set) types.Set {
+	var elements []attr.Value
+	for _, element := range a.Elements() {
+		if slices.ContainsFunc(b.Elements(), element.Equal) {
+			elements = append(elements, element)
+		}
+	}
+
+	if len(elements) == 0 {
+		return types.SetNull(a.ElementType(context.Background()))
+	}
+	return types.SetValueMust(a.ElementType(context.Background()), elements)
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func stringSet(values ...string) types.Set {
+	elements := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elements = append(elements, types.StringValue(v))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+func TestSetIntersectionReturnsCommonElements(t *testing.T) {
+	result := SetIntersection(stringSet("a", "b", "c"), stringSet("b", "c", "d"))
+	if !result.Equal(stringSet("b", "c")) {
+		t.Errorf("expected [b c], got %s", result)
+	}
+}
+
+func TestSetIntersectionReturnsNullWhenEmpty(t *testing.T) {
+	result := SetIntersection(stringSet("a"), stringSet("b"))
+	if !result.IsNull() {
+		t.Errorf("expected null set, got %s", result)
+	}
+}
+
+func TestSetIntersectionWithNullSet(t *testing.T) {
+	result := SetIntersection(stringSet("a"), types.SetNull(types.StringType))
+	if !result.IsNull() {
+		t.Errorf("expected null set, got %s", result)
+	}
+}
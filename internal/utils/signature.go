@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// WebhookSignatureHeader is the request header holding the signature of a
+// webhook message.
+const WebhookSignatureHeader = "x-bs-signature"
+
+// VerifyWebhookSignature reports whether signature is the hex encoded
+// HMAC-SHA256 of the payload using the webhook secret.
+func VerifyWebhookSignature(payload []byte, secret string, signature string) bool {
+	actual, err := hex.DecodeString(strings.TrimSpace(signature))
+	if err != nil || len(actual) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(actual, mac.Sum(nil))
+}
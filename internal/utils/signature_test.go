@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"testing"
+)
+
+const (
+	testWebhookPayload   = `{"eventType":"PRODUCT_CREATED"}`
+	testWebhookSignature = "3733c25bea8657a4f62332581dc1dbeeed0461588cc4fc086b463732f2f9b9b6"
+)
+
+func TestVerifyWebhookSignatureAcceptsHex(t *testing.T) {
+	if !VerifyWebhookSignature([]byte(testWebhookPayload), "secret", testWebhookSignature) {
+		t.Errorf("expected signature %s to be valid", testWebhookSignature)
+	}
+}
+
+func TestVerifyWebhookSignatureRejectsInvalid(t *testing.T) {
+	payload := []byte(testWebhookPayload)
+	if VerifyWebhookSignature(payload, "other", testWebhookSignature) {
+		t.Error("expected signature with wrong secret to be invalid")
+	}
+	if VerifyWebhookSignature([]byte(`{}`), "secret", testWebhookSignature) {
+		t.Error("expected signature of other payload to be invalid")
+	}
+	if VerifyWebhookSignature(payload, "secret", "") {
+		t.Error("expected empty signature to be invalid")
+	}
+	if VerifyWebhookSignature(payload, "secret", "NzPCW+qGV6T2IzJYHcHb7u0EYViMxPwIa0Y3MvL5ubY=") {
+		t.Error("expected base64 encoded signature to be invalid")
+	}
+}
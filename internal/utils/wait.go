@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// DefaultTimeout is the default time to wait for a change to become visible
+// in Bluestone PIM.
+const DefaultTimeout = 5 * time.Minute
+
+// pollInterval is the initial interval between two checks of WaitFor. It is
+// doubled after every check, up to maxPollInterval.
+var pollInterval = 500 * time.Millisecond
+
+const maxPollInterval = 5 * time.Second
+
+// TimeoutsBlock returns the timeouts block of the resources, configuring
+// how long to wait for changes to become visible.
+func TimeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create: true,
+		Update: true,
+		Delete: true,
+	})
+}
+
+// NullTimeouts returns an empty timeouts block, for states that are not
+// created from a plan.
+func NullTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
+	}
+}
+
+// WaitFor calls check until it reports the change described by description
+// is visible. Bluestone PIM processes some changes asynchronously and returns
+// before they are visible, and new objects are not always found right after
+// creating them. The checks bypass the read cache. Waiting stops when the
+// context is done, so callers set a deadline from the configured timeouts.
+func WaitFor(ctx context.Context, description string, check func(ctx context.Context) (bool, diag.Diagnostic)) diag.Diagnostic {
+	ctx = WithFreshReads(ctx)
+	interval := pollInterval
+	for {
+		done, d := check(ctx)
+		if ctx.Err() != nil {
+			return timeoutDiagnostic(description)
+		}
+		if d != nil {
+			return d
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return timeoutDiagnostic(description)
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, maxPollInterval)
+	}
+}
+
+func timeoutDiagnostic(description string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Timed out waiting for change",
+		fmt.Sprintf("Timed out waiting for %s. Increase the timeout in the timeouts block when Bluestone PIM "+
+			"needs more time to process the change.", description),
+	)
+}
+
+// IsNotFound returns whether the response is a 404 Not Found.
+func IsNotFound(response Response) bool {
+	return response.StatusCode() == http.StatusNotFound
+}
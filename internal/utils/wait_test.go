@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestWaitForPollsUntilDone(t *testing.T) {
+	pollInterval = time.Millisecond
+	calls := 0
+	d := WaitFor(context.Background(), "the change", func(ctx context.Context) (bool, diag.Diagnostic) {
+		calls++
+		return calls == 3, nil
+	})
+	if d != nil {
+		t.Fatalf("unexpected diagnostic: %s", d.Detail())
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 checks, got %d", calls)
+	}
+}
+
+func TestWaitForReturnsCheckDiagnostic(t *testing.T) {
+	pollInterval = time.Millisecond
+	d := WaitFor(context.Background(), "the change", func(ctx context.Context) (bool, diag.Diagnostic) {
+		return false, diag.NewErrorDiagnostic("failed", "check failed")
+	})
+	if d == nil || d.Detail() != "check failed" {
+		t.Errorf("expected the check diagnostic, got %v", d)
+	}
+}
+
+func TestWaitForTimesOut(t *testing.T) {
+	pollInterval = time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	d := WaitFor(ctx, "the change", func(ctx context.Context) (bool, diag.Diagnostic) {
+		return false, nil
+	})
+	if d == nil || d.Summary() != "Timed out waiting for change" {
+		t.Errorf("expected a timeout, got %v", d)
+	}
+}
@@ -0,0 +1,115 @@
+// Package webhooklisten implements the webhook-listen command, a local HTTP
+// receiver to debug webhook integrations. It verifies the signature of every
+// message and prints the events it receives.
+package webhooklisten
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+// Command is the name of the subcommand.
+const Command = "webhook-listen"
+
+// maxPayloadSize is the largest message body the receiver accepts.
+const maxPayloadSize = 10 << 20
+
+// Run parses the command line arguments and serves until interrupted.
+func Run(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet(Command, flag.ContinueOnError)
+	secret := flags.String("secret", os.Getenv("BLUESTONEPIM_WEBHOOK_SECRET"),
+		"Webhook secret used to verify the x-bs-signature header (env BLUESTONEPIM_WEBHOOK_SECRET).")
+	addr := flags.String("addr", "localhost:8080", "Address to listen on.")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *secret == "" {
+		return errors.New("a secret is required, pass --secret or set BLUESTONEPIM_WEBHOOK_SECRET")
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           NewHandler(*secret, out),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	_, _ = fmt.Fprintf(out, "Listening for webhook messages on http://%s\n", *addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NewHandler returns a handler that verifies the signature of each message
+// and prints it to out. Messages with an invalid signature are rejected with
+// 401 Unauthorized, so the delivery shows up as failed in Bluestone PIM.
+func NewHandler(secret string, out io.Writer) http.Handler {
+	var mu sync.Mutex
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		payload, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+		if err != nil {
+			http.Error(w, "failed reading body", http.StatusBadRequest)
+			return
+		}
+
+		signature := r.Header.Get(utils.WebhookSignatureHeader)
+		valid := utils.VerifyWebhookSignature(payload, secret, signature)
+
+		mu.Lock()
+		printMessage(out, r, payload, valid)
+		mu.Unlock()
+
+		if !valid {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func printMessage(out io.Writer, r *http.Request, payload []byte, valid bool) {
+	status := "valid"
+	if !valid {
+		status = "INVALID"
+		if r.Header.Get(utils.WebhookSignatureHeader) == "" {
+			status = "MISSING"
+		}
+	}
+
+	_, _ = fmt.Fprintf(out, "--- %s %s %s (signature %s)\n",
+		time.Now().UTC().Format(time.RFC3339), r.Method, r.URL.Path, status)
+
+	var body bytes.Buffer
+	if err := json.Indent(&body, payload, "", "  "); err != nil {
+		body.Reset()
+		body.Write(payload)
+	}
+	_, _ = fmt.Fprintln(out, body.String())
+}
@@ -0,0 +1,68 @@
+package webhooklisten
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labd/terraform-provider-bluestonepim/internal/utils"
+)
+
+const (
+	payload   = `{"eventType":"PRODUCT_CREATED"}`
+	signature = "3733c25bea8657a4f62332581dc1dbeeed0461588cc4fc086b463732f2f9b9b6"
+)
+
+func deliver(t *testing.T, method string, signature string) (*httptest.ResponseRecorder, string) {
+	t.Helper()
+	var out bytes.Buffer
+	request := httptest.NewRequest(method, "/webhook", strings.NewReader(payload))
+	if signature != "" {
+		request.Header.Set(utils.WebhookSignatureHeader, signature)
+	}
+	recorder := httptest.NewRecorder()
+	NewHandler("secret", &out).ServeHTTP(recorder, request)
+	return recorder, out.String()
+}
+
+func TestHandlerAcceptsValidSignature(t *testing.T) {
+	recorder, out := deliver(t, http.MethodPost, signature)
+	if recorder.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", recorder.Code)
+	}
+	if !strings.Contains(out, "(signature valid)") || !strings.Contains(out, `"eventType": "PRODUCT_CREATED"`) {
+		t.Errorf("unexpected output %q", out)
+	}
+}
+
+func TestHandlerRejectsInvalidSignature(t *testing.T) {
+	recorder, out := deliver(t, http.MethodPost, strings.Repeat("0", 64))
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", recorder.Code)
+	}
+	if !strings.Contains(out, "(signature INVALID)") {
+		t.Errorf("unexpected output %q", out)
+	}
+}
+
+func TestHandlerRejectsMissingSignature(t *testing.T) {
+	recorder, out := deliver(t, http.MethodPost, "")
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", recorder.Code)
+	}
+	if !strings.Contains(out, "(signature MISSING)") {
+		t.Errorf("unexpected output %q", out)
+	}
+}
+
+func TestHandlerRejectsOtherMethods(t *testing.T) {
+	recorder, out := deliver(t, http.MethodGet, signature)
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", recorder.Code)
+	}
+	if out != "" {
+		t.Errorf("expected no output, got %q", out)
+	}
+}
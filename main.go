@@ -5,10 +5,12 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
 	"github.com/labd/terraform-provider-bluestonepim/internal/provider"
+	"github.com/labd/terraform-provider-bluestonepim/internal/webhooklisten"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -29,6 +31,15 @@ var (
 )
 
 func main() {
+	// Run the local webhook receiver instead of the provider, to debug
+	// webhook integrations.
+	if len(os.Args) > 1 && os.Args[1] == webhooklisten.Command {
+		if err := webhooklisten.Run(os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	debugFlag := flag.Bool("debug", false, "Start provider in debug mode.")
 	flag.Parse()
 